@@ -0,0 +1,185 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/http/utils"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+)
+
+const defaultArmingTimeout = 30 * time.Second
+
+// ArmingToken is the token returned by the SET command arming a device command, or device resource, which
+// requires select-before-operate. Expiry is the Unix time in nanoseconds after which the token is invalid.
+type ArmingToken struct {
+	Token  string `json:"token"`
+	Expiry int64  `json:"expiry"`
+}
+
+// ArmingResponse is the response of the SET command arming a device command, or device resource.
+type ArmingResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	ArmingToken            `json:",inline"`
+}
+
+// NewArmingResponse creates an ArmingResponse with the given ArmingToken.
+func NewArmingResponse(requestId string, statusCode int, token ArmingToken) ArmingResponse {
+	return ArmingResponse{
+		BaseResponse: commonDTO.NewBaseResponse(requestId, "", statusCode),
+		ArmingToken:  token,
+	}
+}
+
+// armedCommand is a SET command which has been armed and waits for its arming token to be presented.
+type armedCommand struct {
+	deviceName    string
+	commandName   string
+	correlationId string
+	requests      map[string]any
+	expiry        time.Time
+}
+
+var armedCommands = struct {
+	mutex    sync.Mutex
+	commands map[string]*armedCommand
+}{
+	commands: make(map[string]*armedCommand),
+}
+
+// armingRequired returns whether the SET commands of the device command, or device resource, have to be armed
+// before being operated, i.e. whether the ds-arm tag of the device command, or the ds-arm attribute of any of
+// the written device resources, is true.
+func armingRequired(device models.Device, commandName string) bool {
+	if dc, ok := cache.Profiles().DeviceCommand(device.ProfileName, commandName); ok {
+		if fmt.Sprint(dc.Tags[sdkCommon.Arm]) == common.ValueTrue {
+			return true
+		}
+		for _, ro := range dc.ResourceOperations {
+			if resourceArmingRequired(device, ro.DeviceResource) {
+				return true
+			}
+		}
+		return false
+	}
+	return resourceArmingRequired(device, commandName)
+}
+
+func resourceArmingRequired(device models.Device, resourceName string) bool {
+	dr, ok := cache.Profiles().DeviceResource(device.ProfileName, resourceName)
+	return ok && fmt.Sprint(dr.Attributes[sdkCommon.Arm]) == common.ValueTrue
+}
+
+// armCommand arms the SET command of the device with the requested values and returns the token which has to
+// be presented to operate it. A command armed by another correlation ID cannot be armed until it expires.
+func armCommand(ctx context.Context, deviceName string, commandName string, requests map[string]any, dic *di.Container) (ArmingToken, errors.EdgeX) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	correlationId := utils.FromContext(ctx, common.CorrelationHeader)
+	now := time.Now()
+
+	armedCommands.mutex.Lock()
+	defer armedCommands.mutex.Unlock()
+
+	purgeExpiredArmedCommands(now, lc)
+	for token, armed := range armedCommands.commands {
+		if armed.deviceName != deviceName || armed.commandName != commandName {
+			continue
+		}
+		if armed.correlationId != correlationId {
+			lc.Warnf("Rejected the arming of command %s of device %s, %s: %s, as it is armed by %s: %s", commandName, deviceName, common.CorrelationHeader, correlationId, common.CorrelationHeader, armed.correlationId)
+			errMsg := fmt.Sprintf("command %s of device %s is already armed until %s", commandName, deviceName, armed.expiry.Format(time.RFC3339))
+			return ArmingToken{}, errors.NewCommonEdgeX(errors.KindStatusConflict, errMsg, nil)
+		}
+		// arming the command again replaces the previous token
+		delete(armedCommands.commands, token)
+	}
+
+	token := uuid.NewString()
+	expiry := now.Add(armingTimeout(dic))
+	armedCommands.commands[token] = &armedCommand{
+		deviceName:    deviceName,
+		commandName:   commandName,
+		correlationId: correlationId,
+		requests:      requests,
+		expiry:        expiry,
+	}
+	lc.Infof("Armed command %s of device %s until %s, %s: %s", commandName, deviceName, expiry.Format(time.RFC3339), common.CorrelationHeader, correlationId)
+	return ArmingToken{Token: token, Expiry: expiry.UnixNano()}, nil
+}
+
+// operateCommand checks the arming token presented by the SET command of the device, which has to be valid,
+// issued for the same command, correlation ID and values, and consumes it.
+func operateCommand(ctx context.Context, deviceName string, commandName string, token string, requests map[string]any, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	correlationId := utils.FromContext(ctx, common.CorrelationHeader)
+
+	armedCommands.mutex.Lock()
+	defer armedCommands.mutex.Unlock()
+
+	purgeExpiredArmedCommands(time.Now(), lc)
+	armed, ok := armedCommands.commands[token]
+	var reason string
+	switch {
+	case !ok:
+		reason = "the arming token is unknown or expired"
+	case armed.deviceName != deviceName || armed.commandName != commandName:
+		reason = fmt.Sprintf("the arming token was issued for command %s of device %s", armed.commandName, armed.deviceName)
+	case armed.correlationId != correlationId:
+		reason = fmt.Sprintf("the arming token was issued for %s %s", common.CorrelationHeader, armed.correlationId)
+	case !reflect.DeepEqual(armed.requests, requests):
+		reason = "the values differ from the armed ones"
+	}
+	if reason != "" {
+		lc.Warnf("Rejected the operation of command %s of device %s, %s: %s: %s", commandName, deviceName, common.CorrelationHeader, correlationId, reason)
+		errMsg := fmt.Sprintf("command %s of device %s cannot be operated: %s", commandName, deviceName, reason)
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+
+	delete(armedCommands.commands, token)
+	lc.Infof("Operating armed command %s of device %s, %s: %s", commandName, deviceName, common.CorrelationHeader, correlationId)
+	return nil
+}
+
+// purgeExpiredArmedCommands removes the armed commands whose tokens expired, the caller holds the mutex.
+func purgeExpiredArmedCommands(now time.Time, lc logger.LoggingClient) {
+	for token, armed := range armedCommands.commands {
+		if now.After(armed.expiry) {
+			delete(armedCommands.commands, token)
+			lc.Infof("Arming of command %s of device %s expired, %s: %s", armed.commandName, armed.deviceName, common.CorrelationHeader, armed.correlationId)
+		}
+	}
+}
+
+func armingTimeout(dic *di.Container) time.Duration {
+	timeout := container.ConfigurationFrom(dic.Get).Device.Arming.Timeout
+	if timeout == "" {
+		return defaultArmingTimeout
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil || duration <= 0 {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Warnf("failed to parse Arming Timeout '%s', using default %s: %v", timeout, defaultArmingTimeout, err)
+		return defaultArmingTimeout
+	}
+	return duration
+}
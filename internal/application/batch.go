@@ -0,0 +1,125 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+)
+
+const defaultBatchCommandWorkers = 8
+
+// BatchCommand identifies a single device command of a batch read request.
+type BatchCommand struct {
+	DeviceName  string `json:"deviceName" validate:"required"`
+	CommandName string `json:"commandName" validate:"required"`
+}
+
+// BatchCommandRequest is the request body of the batch read command API.
+type BatchCommandRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Commands              []BatchCommand `json:"commands" validate:"gt=0,dive"`
+}
+
+// Validate satisfies the Validator interface
+func (r BatchCommandRequest) Validate() errors.EdgeX {
+	err := common.Validate(r)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// BatchCommandResult is the outcome of a single device command of a batch read request.
+type BatchCommandResult struct {
+	DeviceName  string      `json:"deviceName"`
+	CommandName string      `json:"commandName"`
+	StatusCode  int         `json:"statusCode"`
+	Message     string      `json:"message,omitempty"`
+	Event       *dtos.Event `json:"event,omitempty"`
+	// Errors are the errors of the device resources missing from the Event of a partial read
+	Errors []ResourceError `json:"errors,omitempty"`
+}
+
+// BatchCommandResponse is the response body of the batch read command API.
+type BatchCommandResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Results                []BatchCommandResult `json:"results"`
+}
+
+// NewBatchCommandResponse creates a BatchCommandResponse with the given results.
+func NewBatchCommandResponse(requestId string, statusCode int, results []BatchCommandResult) BatchCommandResponse {
+	return BatchCommandResponse{
+		BaseResponse: commonDTO.NewBaseResponse(requestId, "", statusCode),
+		Results:      results,
+	}
+}
+
+// GetCommands executes the given GET device commands through GetCommand using a bounded pool of
+// workers. The returned results are in the same order as the commands, each of them carrying
+// either the read Event or the error of the individual command.
+func GetCommands(ctx context.Context, commands []BatchCommand, queryParams string, regexCmd bool, maxAge time.Duration, units string, dic *di.Container) []BatchCommandResult {
+	results := make([]BatchCommandResult, len(commands))
+	if len(commands) == 0 {
+		return results
+	}
+
+	workers := container.ConfigurationFrom(dic.Get).Device.BatchCommandWorkers
+	if workers <= 0 {
+		workers = defaultBatchCommandWorkers
+	}
+	if workers > len(commands) {
+		workers = len(commands)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				cmd := commands[index]
+				result := BatchCommandResult{
+					DeviceName:  cmd.DeviceName,
+					CommandName: cmd.CommandName,
+					StatusCode:  http.StatusOK,
+				}
+				event, resourceErrors, err := GetCommand(ctx, cmd.DeviceName, cmd.CommandName, queryParams, regexCmd, maxAge, units, dic)
+				if err != nil {
+					result.StatusCode = err.Code()
+					result.Message = err.Error()
+				} else {
+					result.Event = event
+					if len(resourceErrors) > 0 {
+						result.StatusCode = http.StatusMultiStatus
+						result.Errors = resourceErrors
+					}
+				}
+				results[index] = result
+			}
+		}()
+	}
+
+	for i := range commands {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,228 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultProbeInterval    = 30 * time.Second
+)
+
+// deviceBreaker counts the consecutive failed ProtocolDriver calls of a device, it is open
+// while the device is marked DOWN and being probed.
+type deviceBreaker struct {
+	failures int
+	open     bool
+}
+
+var circuitBreakers = struct {
+	mutex    sync.Mutex
+	breakers map[string]*deviceBreaker
+}{
+	breakers: make(map[string]*deviceBreaker),
+}
+
+// recordDriverResult updates the circuit breaker of the device with the outcome of a ProtocolDriver
+// call and opens it once the FailureThreshold is reached. A successful call removes the circuit breaker
+// of the device unless it is open. Calls canceled by the caller are ignored.
+func recordDriverResult(ctx context.Context, device models.Device, err errors.EdgeX, dic *di.Container) {
+	breakerInfo := container.ConfigurationFrom(dic.Get).Device.CircuitBreaker
+	if !breakerInfo.Enabled || ctx.Err() == context.Canceled {
+		return
+	}
+
+	circuitBreakers.mutex.Lock()
+	defer circuitBreakers.mutex.Unlock()
+
+	breaker, ok := circuitBreakers.breakers[device.Name]
+	if err == nil {
+		if ok && !breaker.open {
+			delete(circuitBreakers.breakers, device.Name)
+		}
+		return
+	}
+	if !ok {
+		breaker = &deviceBreaker{}
+		circuitBreakers.breakers[device.Name] = breaker
+	}
+
+	breaker.failures++
+	threshold := breakerInfo.FailureThreshold
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	if breaker.failures >= threshold && !breaker.open {
+		breaker.open = true
+		go openCircuitBreaker(device.Name, breaker.failures, dic)
+	}
+}
+
+// openCircuitBreaker marks the device DOWN, stops its AutoEvents and probes it until it recovers.
+func openCircuitBreaker(deviceName string, failures int, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Warnf("%d consecutive ProtocolDriver calls for device %s failed, marking the device DOWN", failures, deviceName)
+
+	if err := cache.Devices().UpdateOperatingState(deviceName, models.Down); err != nil {
+		lc.Errorf("failed to mark device %s DOWN: %v", deviceName, err)
+		closeCircuitBreaker(deviceName)
+		return
+	}
+	sdkCommon.UpdateOperatingState(deviceName, models.Down, lc, bootstrapContainer.DeviceClientFrom(dic.Get))
+	if dic.Get(container.AutoEventManagerName) != nil {
+		container.AutoEventManagerFrom(dic.Get).StopForDevice(deviceName)
+	}
+
+	ticker := time.NewTicker(circuitBreakerProbeInterval(dic))
+	defer ticker.Stop()
+	for range ticker.C {
+		device, ok := cache.Devices().ForName(deviceName)
+		if !ok {
+			lc.Debugf("stop probing device %s as it has been removed", deviceName)
+			closeCircuitBreaker(deviceName)
+			return
+		}
+		if device.OperatingState != models.Down {
+			lc.Debugf("stop probing device %s as its OperatingState has been changed to %s", deviceName, device.OperatingState)
+			closeCircuitBreaker(deviceName)
+			return
+		}
+
+		if err := probeDevice(device, dic); err != nil {
+			lc.Debugf("probing device %s failed: %v", deviceName, err)
+			continue
+		}
+
+		lc.Infof("probing device %s succeeded, marking the device UP", deviceName)
+		closeCircuitBreaker(deviceName)
+		if err := cache.Devices().UpdateOperatingState(deviceName, models.Up); err != nil {
+			lc.Errorf("failed to mark device %s UP: %v", deviceName, err)
+			return
+		}
+		sdkCommon.UpdateOperatingState(deviceName, models.Up, lc, bootstrapContainer.DeviceClientFrom(dic.Get))
+		if device.AdminState == models.Unlocked && dic.Get(container.AutoEventManagerName) != nil {
+			container.AutoEventManagerFrom(dic.Get).RestartForDevice(deviceName)
+		}
+		return
+	}
+}
+
+// circuitBreakerProbeInterval returns the configured ProbeInterval, falling back to the default one if it
+// is not a positive duration as time.NewTicker panics otherwise.
+func circuitBreakerProbeInterval(dic *di.Container) time.Duration {
+	interval := container.ConfigurationFrom(dic.Get).Device.CircuitBreaker.ProbeInterval
+	if interval == "" {
+		return defaultProbeInterval
+	}
+	duration, err := time.ParseDuration(interval)
+	if err != nil || duration <= 0 {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Warnf("failed to parse CircuitBreaker ProbeInterval '%s', using default %s: %v", interval, defaultProbeInterval, err)
+		return defaultProbeInterval
+	}
+	return duration
+}
+
+func closeCircuitBreaker(deviceName string) {
+	circuitBreakers.mutex.Lock()
+	defer circuitBreakers.mutex.Unlock()
+
+	delete(circuitBreakers.breakers, deviceName)
+}
+
+// probeDevice reads the probe resource of the device bypassing the OperatingState check of the commands.
+func probeDevice(device models.Device, dic *di.Container) errors.EdgeX {
+	reqs, ok := probeRequests(device, dic)
+	if !ok {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "no readable resource to probe the device", nil)
+	}
+
+	ctx, cancel := commandContext(context.Background(), device.Name, reqs[0].DeviceResourceName, dic)
+	defer cancel()
+
+	release, err := acquireDriverAccess(ctx, device, dic)
+	if err != nil {
+		return err
+	}
+	_, _, err = callReadCommands(ctx, device, reqs, release, dic)
+	return err
+}
+
+// probeRequests returns the CommandRequests of the configured probe resource of the device profile,
+// falling back to the first AutoEvent source of the device and then to the first readable resource.
+func probeRequests(device models.Device, dic *di.Container) ([]sdkModels.CommandRequest, bool) {
+	var sources []string
+	if source, ok := container.ConfigurationFrom(dic.Get).Device.CircuitBreaker.ProbeResources[device.ProfileName]; ok {
+		sources = append(sources, source)
+	}
+	for _, autoEvent := range device.AutoEvents {
+		sources = append(sources, autoEvent.SourceName)
+	}
+	for _, source := range sources {
+		if reqs, ok := sourceRequests(device.ProfileName, source); ok {
+			return reqs, true
+		}
+	}
+
+	profile, ok := cache.Profiles().ForName(device.ProfileName)
+	if !ok {
+		return nil, false
+	}
+	for _, dr := range profile.DeviceResources {
+		if dr.Properties.ReadWrite != common.ReadWrite_W {
+			return []sdkModels.CommandRequest{newCommandRequest(dr)}, true
+		}
+	}
+	return nil, false
+}
+
+// sourceRequests returns the CommandRequests to read the given device command or device resource.
+func sourceRequests(profileName string, sourceName string) ([]sdkModels.CommandRequest, bool) {
+	if dc, ok := cache.Profiles().DeviceCommand(profileName, sourceName); ok {
+		if dc.ReadWrite == common.ReadWrite_W {
+			return nil, false
+		}
+		reqs := make([]sdkModels.CommandRequest, 0, len(dc.ResourceOperations))
+		for _, op := range dc.ResourceOperations {
+			dr, ok := cache.Profiles().DeviceResource(profileName, op.DeviceResource)
+			if !ok {
+				return nil, false
+			}
+			reqs = append(reqs, newCommandRequest(dr))
+		}
+		return reqs, len(reqs) > 0
+	}
+
+	dr, ok := cache.Profiles().DeviceResource(profileName, sourceName)
+	if !ok || dr.Properties.ReadWrite == common.ReadWrite_W {
+		return nil, false
+	}
+	return []sdkModels.CommandRequest{newCommandRequest(dr)}, true
+}
+
+func newCommandRequest(dr models.DeviceResource) sdkModels.CommandRequest {
+	return sdkModels.CommandRequest{
+		DeviceResourceName: dr.Name,
+		Attributes:         dr.Attributes,
+		Type:               dr.Properties.ValueType,
+	}
+}
@@ -0,0 +1,155 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	driver := &mocks.ProtocolDriver{}
+	// the reads fail until the device is marked DOWN, then the probe succeeds
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return(nil, stdErrors.New("no response")).Times(3)
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{stringValue(t, resource1, "on")}, nil)
+	dic := mockDic(t, &config.ConfigurationStruct{
+		Device: config.DeviceInfo{
+			CircuitBreaker: config.CircuitBreakerInfo{Enabled: true, FailureThreshold: 3, ProbeInterval: "10ms"},
+		},
+	}, driver)
+	autoEventManager := &autoEventManagerStub{}
+	dic.Update(di.ServiceConstructorMap{
+		container.AutoEventManagerName: func(get di.Get) any {
+			return autoEventManager
+		},
+	})
+	device := testDeviceModel(t, testDevice)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := driverReadCommands(context.Background(), device, commandRequests(resource1), dic)
+		require.Error(t, err)
+	}
+	// the device stays UP below the FailureThreshold
+	assert.EqualValues(t, models.Up, testDeviceModel(t, testDevice).OperatingState)
+	stopped, _ := autoEventManager.calls()
+	assert.Empty(t, stopped)
+
+	_, _, err := driverReadCommands(context.Background(), device, commandRequests(resource1), dic)
+	require.Error(t, err)
+
+	// the device is marked DOWN and its AutoEvents stopped, until the probe brings it back UP
+	require.Eventually(t, func() bool {
+		stopped, _ := autoEventManager.calls()
+		return len(stopped) == 1
+	}, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, restarted := autoEventManager.calls()
+		return len(restarted) == 1
+	}, time.Second, time.Millisecond)
+	stopped, restarted := autoEventManager.calls()
+	assert.Equal(t, []string{testDevice}, stopped)
+	assert.Equal(t, []string{testDevice}, restarted)
+	assert.EqualValues(t, models.Up, testDeviceModel(t, testDevice).OperatingState)
+
+	driver.AssertNumberOfCalls(t, "HandleReadCommands", 4)
+
+	// the OperatingState is updated in Core Metadata as well
+	deviceClient := bootstrapContainer.DeviceClientFrom(dic.Get).(*clientMocks.DeviceClient)
+	var states []string
+	for _, call := range deviceClient.Calls {
+		if call.Method == "Update" {
+			reqs := call.Arguments.Get(1).([]requests.UpdateDeviceRequest)
+			states = append(states, *reqs[0].Device.OperatingState)
+		}
+	}
+	assert.Equal(t, []string{models.Down, models.Up}, states)
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", otherDevice, mock.Anything, mock.Anything).Return(nil, stdErrors.New("no response")).Once()
+	driver.On("HandleReadCommands", otherDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{stringValue(t, resource1, "on")}, nil).Once()
+	driver.On("HandleReadCommands", otherDevice, mock.Anything, mock.Anything).Return(nil, stdErrors.New("no response"))
+	dic := mockDic(t, &config.ConfigurationStruct{
+		Device: config.DeviceInfo{
+			CircuitBreaker: config.CircuitBreakerInfo{Enabled: true, FailureThreshold: 2, ProbeInterval: "1h"},
+		},
+	}, driver)
+	device := testDeviceModel(t, otherDevice)
+
+	// failure, success, failure never reaches 2 consecutive failures
+	for i := 0; i < 3; i++ {
+		_, _, _ = driverReadCommands(context.Background(), device, commandRequests(resource1), dic)
+	}
+	assert.EqualValues(t, models.Up, testDeviceModel(t, otherDevice).OperatingState)
+
+	_, _, _ = driverReadCommands(context.Background(), device, commandRequests(resource1), dic)
+	require.Eventually(t, func() bool {
+		d, ok := cache.Devices().ForName(otherDevice)
+		return ok && d.OperatingState == models.Down
+	}, time.Second, time.Millisecond)
+	closeCircuitBreaker(otherDevice)
+}
+
+func TestCircuitBreaker_SuccessRemovesBreaker(t *testing.T) {
+	dic := mockDic(t, &config.ConfigurationStruct{
+		Device: config.DeviceInfo{CircuitBreaker: config.CircuitBreakerInfo{Enabled: true, FailureThreshold: 5}},
+	}, &mocks.ProtocolDriver{})
+	device := testDeviceModel(t, otherDevice)
+
+	// the devices whose calls succeed keep no circuit breaker
+	recordDriverResult(context.Background(), device, nil, dic)
+	circuitBreakers.mutex.Lock()
+	assert.NotContains(t, circuitBreakers.breakers, otherDevice)
+	circuitBreakers.mutex.Unlock()
+
+	recordDriverResult(context.Background(), device, errors.NewCommonEdgeXWrapper(stdErrors.New("no response")), dic)
+	recordDriverResult(context.Background(), device, nil, dic)
+	circuitBreakers.mutex.Lock()
+	defer circuitBreakers.mutex.Unlock()
+	assert.NotContains(t, circuitBreakers.breakers, otherDevice)
+}
+
+func TestCircuitBreakerProbeInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval string
+		expected time.Duration
+	}{
+		{"default", "", defaultProbeInterval},
+		{"valid", "5s", 5 * time.Second},
+		{"invalid", "soon", defaultProbeInterval},
+		{"zero", "0s", defaultProbeInterval},
+		{"negative", "-1s", defaultProbeInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dic := mockDic(t, &config.ConfigurationStruct{
+				Device: config.DeviceInfo{CircuitBreaker: config.CircuitBreakerInfo{ProbeInterval: tt.interval}},
+			}, &mocks.ProtocolDriver{})
+			assert.Equal(t, tt.expected, circuitBreakerProbeInterval(dic))
+		})
+	}
+}
@@ -9,6 +9,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
@@ -107,6 +108,7 @@ func UpdateDevice(updateDeviceRequest requests.UpdateDeviceRequest, dic *di.Cont
 		return DeleteDevice(*updateDeviceRequest.Device.Name, dic)
 	}
 
+	protocols := device.Protocols
 	requests.ReplaceDeviceModelFieldsWithDTO(&device, updateDeviceRequest.Device)
 	edgexErr := updateAssociatedProfile(device.ProfileName, dic)
 	if edgexErr != nil {
@@ -118,6 +120,13 @@ func UpdateDevice(updateDeviceRequest requests.UpdateDeviceRequest, dic *di.Cont
 		errMsg := fmt.Sprintf("failed to update device %s", device.Name)
 		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, edgexErr)
 	}
+	// the cached values may not match the updated protocols or profile anymore
+	cache.Readings().RemoveByDeviceName(device.Name)
+	if !reflect.DeepEqual(protocols, device.Protocols) {
+		// the device may not share the limiters of its previous protocol properties anymore, it gets the
+		// limiters of the updated ones on its next ProtocolDriver call
+		removeAccessLimiters(device.Name)
+	}
 	lc.Debugf("device %s updated", device.Name)
 
 	driver := container.ProtocolDriverFrom(dic.Get)
@@ -133,6 +142,10 @@ func UpdateDevice(updateDeviceRequest requests.UpdateDeviceRequest, dic *di.Cont
 	if device.AdminState == models.Locked {
 		lc.Debugf("stopping AutoEvents for the locked device %s", device.Name)
 		autoEventManager.StopForDevice(device.Name)
+	} else if device.OperatingState == models.Down && container.ConfigurationFrom(dic.Get).Device.CircuitBreaker.Enabled {
+		// the circuit breaker restarts the AutoEvents once the device is back UP
+		lc.Debugf("stopping AutoEvents for the DOWN device %s", device.Name)
+		autoEventManager.StopForDevice(device.Name)
 	} else {
 		lc.Debugf("starting AutoEvents for device %s", device.Name)
 		autoEventManager.RestartForDevice(device.Name)
@@ -142,22 +155,25 @@ func UpdateDevice(updateDeviceRequest requests.UpdateDeviceRequest, dic *di.Cont
 
 func DeleteDevice(name string, dic *di.Container) errors.EdgeX {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
-	// check the device exist and stop its autoevents
+	// check the device exist
 	device, ok := cache.Devices().ForName(name)
-	if ok {
-		lc.Debugf("stopping AutoEvents for device %s", device.Name)
-		container.AutoEventManagerFrom(dic.Get).StopForDevice(device.Name)
-	} else {
+	if !ok {
 		errMsg := fmt.Sprintf("failed to find device %s", name)
 		return errors.NewCommonEdgeX(errors.KindInvalidId, errMsg, nil)
 	}
 
-	// remove the device in cache
+	// remove the device in cache and stop its autoevents, which forgets the paused ones
 	edgexErr := cache.Devices().RemoveByName(name)
 	if edgexErr != nil {
 		errMsg := fmt.Sprintf("failed to remove device %s", device.Name)
 		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, edgexErr)
 	}
+	lc.Debugf("stopping AutoEvents for device %s", device.Name)
+	container.AutoEventManagerFrom(dic.Get).StopForDevice(device.Name)
+	cache.Readings().RemoveByDeviceName(device.Name)
+	unregisterDriverCallRetries(device.Name, dic)
+	removeAccessLimiters(device.Name)
+	closeCircuitBreaker(device.Name)
 	lc.Debugf("Removed device: %s", device.Name)
 
 	driver := container.ProtocolDriverFrom(dic.Get)
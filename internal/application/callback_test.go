@@ -0,0 +1,124 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces/mocks"
+)
+
+// callbackDic returns the container of the device callbacks with the given configuration.
+func callbackDic(t *testing.T, configuration *config.ConfigurationStruct) (*di.Container, *autoEventManagerStub) {
+	driver := &mocks.ProtocolDriver{}
+	driver.On("UpdateDevice", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	driver.On("RemoveDevice", mock.Anything, mock.Anything).Return(nil)
+	dic := mockDic(t, configuration, driver)
+	autoEventManager := &autoEventManagerStub{}
+	dic.Update(di.ServiceConstructorMap{
+		container.DeviceServiceName: func(get di.Get) any {
+			return &models.DeviceService{Name: testService}
+		},
+		container.AutoEventManagerName: func(get di.Get) any {
+			return autoEventManager
+		},
+	})
+	return dic, autoEventManager
+}
+
+func updateOperatingStateRequest(deviceName string, state string) requests.UpdateDeviceRequest {
+	serviceName := testService
+	return requests.UpdateDeviceRequest{
+		Device: dtos.UpdateDevice{Name: &deviceName, ServiceName: &serviceName, OperatingState: &state},
+	}
+}
+
+func TestUpdateDevice_Down(t *testing.T) {
+	tests := []struct {
+		name              string
+		breakerEnabled    bool
+		expectedStopped   []string
+		expectedRestarted []string
+	}{
+		{"circuit breaker enabled", true, []string{testDevice}, nil},
+		{"circuit breaker disabled", false, nil, []string{testDevice}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dic, autoEventManager := callbackDic(t, &config.ConfigurationStruct{
+				Device: config.DeviceInfo{CircuitBreaker: config.CircuitBreakerInfo{Enabled: tt.breakerEnabled}},
+			})
+
+			err := UpdateDevice(updateOperatingStateRequest(testDevice, models.Down), dic)
+			require.NoError(t, err)
+			stopped, restarted := autoEventManager.calls()
+			assert.Equal(t, tt.expectedStopped, stopped)
+			assert.Equal(t, tt.expectedRestarted, restarted)
+		})
+	}
+}
+
+func TestDeleteDevice_RemovesCircuitBreaker(t *testing.T) {
+	dic, _ := callbackDic(t, &config.ConfigurationStruct{
+		Device: config.DeviceInfo{CircuitBreaker: config.CircuitBreakerInfo{Enabled: true, FailureThreshold: 5}},
+	})
+	device := testDeviceModel(t, testDevice)
+	recordDriverResult(context.Background(), device, errors.NewCommonEdgeXWrapper(stdErrors.New("no response")), dic)
+	circuitBreakers.mutex.Lock()
+	assert.Contains(t, circuitBreakers.breakers, testDevice)
+	circuitBreakers.mutex.Unlock()
+
+	require.NoError(t, DeleteDevice(testDevice, dic))
+	circuitBreakers.mutex.Lock()
+	defer circuitBreakers.mutex.Unlock()
+	assert.NotContains(t, circuitBreakers.breakers, testDevice)
+}
+
+func TestUpdateDevice_ProtocolsRemoveAccessLimiters(t *testing.T) {
+	dic, _ := callbackDic(t, &config.ConfigurationStruct{
+		Device: config.DeviceInfo{AccessLimits: config.AccessLimitsInfo{
+			Protocols: map[string]config.AccessLimitInfo{testProtocol: {MaxConcurrent: 1, Property: "Address"}},
+		}},
+	})
+	t.Cleanup(func() {
+		removeAccessLimiters(testDevice)
+	})
+	hasLimiter := func(name string) bool {
+		accessLimiters.mutex.Lock()
+		defer accessLimiters.mutex.Unlock()
+		_, ok := accessLimiters.limiters[name]
+		return ok
+	}
+	deviceAccessLimiters(testDeviceModel(t, testDevice), dic)
+	require.True(t, hasLimiter("modbus-tcp Address 10.0.0.1"))
+
+	// the limiter of the previous address is not leaked once the device moves to another one
+	name, serviceName := testDevice, testService
+	req := requests.UpdateDeviceRequest{Device: dtos.UpdateDevice{
+		Name:        &name,
+		ServiceName: &serviceName,
+		Protocols:   map[string]dtos.ProtocolProperties{testProtocol: {"Address": "10.0.0.2"}},
+	}}
+	require.NoError(t, UpdateDevice(req, dic))
+	assert.False(t, hasLimiter("modbus-tcp Address 10.0.0.1"))
+
+	deviceAccessLimiters(testDeviceModel(t, testDevice), dic)
+	assert.True(t, hasLimiter("modbus-tcp Address 10.0.0.2"))
+}
@@ -0,0 +1,210 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+const defaultReadCoalescingWindow = 10 * time.Millisecond
+
+// coalescedRead is a single caller's read waiting for its batch to be flushed.
+type coalescedRead struct {
+	reqs     []sdkModels.CommandRequest
+	results  []*sdkModels.CommandValue
+	failures map[string]errors.EdgeX
+	err      errors.EdgeX
+	done     chan struct{}
+}
+
+// readBatch collects the reads of a device until the coalescing window elapses.
+type readBatch struct {
+	device    models.Device
+	reads     []*coalescedRead
+	resources map[string]struct{}
+	// deadline is the latest deadline of the callers, unbounded is set if any caller has no deadline
+	deadline  time.Time
+	unbounded bool
+}
+
+var readCoalescer = struct {
+	mutex   sync.Mutex
+	batches map[string]*readBatch
+}{
+	batches: make(map[string]*readBatch),
+}
+
+// readCoalescingWindow returns the coalescing window and true if the given reads can be coalesced.
+// Reads with query parameters are never coalesced as the driver may handle them differently.
+func readCoalescingWindow(reqs []sdkModels.CommandRequest, dic *di.Container) (time.Duration, bool) {
+	coalescing := container.ConfigurationFrom(dic.Get).Device.ReadCoalescing
+	if !coalescing.Enabled {
+		return 0, false
+	}
+	if !cacheable(reqs) {
+		return 0, false
+	}
+
+	if coalescing.Window == "" {
+		return defaultReadCoalescingWindow, true
+	}
+	window, err := time.ParseDuration(coalescing.Window)
+	if err != nil {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Warnf("failed to parse ReadCoalescing Window '%s', using default %s: %v", coalescing.Window, defaultReadCoalescingWindow, err)
+		return defaultReadCoalescingWindow, true
+	}
+	if window <= 0 {
+		return 0, false
+	}
+	return window, true
+}
+
+// coalesceReadCommands adds the reads to the pending batch of the device and waits for the batch to be
+// flushed. A new batch is started when adding the reads would exceed MaxCmdOps for the pending one.
+func coalesceReadCommands(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, window time.Duration, dic *di.Container) ([]*sdkModels.CommandValue, map[string]errors.EdgeX, errors.EdgeX) {
+	read := &coalescedRead{reqs: reqs, done: make(chan struct{})}
+	maxCmdOps := container.ConfigurationFrom(dic.Get).Device.MaxCmdOps
+
+	readCoalescer.mutex.Lock()
+	batch, ok := readCoalescer.batches[device.Name]
+	if ok && maxCmdOps > 0 && len(batch.resources)+countNewResources(batch, reqs) > maxCmdOps {
+		// the full batch is detached and left to be flushed by its own timer
+		delete(readCoalescer.batches, device.Name)
+		ok = false
+	}
+	if !ok {
+		batch = &readBatch{device: device, resources: make(map[string]struct{})}
+		readCoalescer.batches[device.Name] = batch
+		time.AfterFunc(window, func() { flushReadBatch(batch, dic) })
+	}
+	batch.reads = append(batch.reads, read)
+	for _, req := range reqs {
+		batch.resources[req.DeviceResourceName] = struct{}{}
+	}
+	if deadline, ok := ctx.Deadline(); !ok {
+		batch.unbounded = true
+	} else if deadline.After(batch.deadline) {
+		batch.deadline = deadline
+	}
+	readCoalescer.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, nil, contextError(ctx, device.Name)
+	case <-read.done:
+		return read.results, read.failures, read.err
+	}
+}
+
+func countNewResources(batch *readBatch, reqs []sdkModels.CommandRequest) int {
+	count := 0
+	for _, req := range reqs {
+		if _, ok := batch.resources[req.DeviceResourceName]; !ok {
+			count++
+		}
+	}
+	return count
+}
+
+// flushReadBatch sends the deduplicated reads of the batch to the driver in a single call and hands
+// each caller the values of the resources it asked for.
+func flushReadBatch(batch *readBatch, dic *di.Container) {
+	readCoalescer.mutex.Lock()
+	if readCoalescer.batches[batch.device.Name] == batch {
+		delete(readCoalescer.batches, batch.device.Name)
+	}
+	readCoalescer.mutex.Unlock()
+
+	reqs := make([]sdkModels.CommandRequest, 0, len(batch.resources))
+	merged := make(map[string]struct{}, len(batch.resources))
+	for _, read := range batch.reads {
+		for _, req := range read.reqs {
+			if _, ok := merged[req.DeviceResourceName]; ok {
+				continue
+			}
+			merged[req.DeviceResourceName] = struct{}{}
+			reqs = append(reqs, req)
+		}
+	}
+
+	// the driver call is bound by the latest deadline of the callers so that none of them is cut short
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if batch.unbounded {
+		ctx, cancel = context.WithCancel(context.Background())
+	} else {
+		ctx, cancel = context.WithDeadline(context.Background(), batch.deadline)
+	}
+	defer cancel()
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debugf("Coalesced %d read(s) of %d resource(s) for device %s into one driver call", len(batch.reads), len(reqs), batch.device.Name)
+
+	results, failures, err := driverReadCommands(ctx, batch.device, reqs, dic)
+	for _, read := range batch.reads {
+		if err != nil {
+			read.err = err
+		} else {
+			read.results = splitReadResults(read.reqs, results)
+			read.failures = splitReadFailures(read.reqs, failures)
+		}
+		close(read.done)
+	}
+}
+
+// splitReadResults picks the values of the requested resources out of the coalesced results. Every caller
+// gets its own copy of the values as they are transformed in place afterwards.
+func splitReadResults(reqs []sdkModels.CommandRequest, results []*sdkModels.CommandValue) []*sdkModels.CommandValue {
+	values := make(map[string]*sdkModels.CommandValue, len(results))
+	for _, cv := range results {
+		if cv != nil {
+			values[cv.DeviceResourceName] = cv
+		}
+	}
+
+	var split []*sdkModels.CommandValue
+	for _, req := range reqs {
+		cv, ok := values[req.DeviceResourceName]
+		if !ok {
+			continue
+		}
+		value := *cv
+		if cv.Tags != nil {
+			value.Tags = make(map[string]string, len(cv.Tags))
+			for k, v := range cv.Tags {
+				value.Tags[k] = v
+			}
+		}
+		split = append(split, &value)
+	}
+	return split
+}
+
+// splitReadFailures picks the errors of the requested resources out of the errors of a coalesced partial read.
+func splitReadFailures(reqs []sdkModels.CommandRequest, failures map[string]errors.EdgeX) map[string]errors.EdgeX {
+	if len(failures) == 0 {
+		return nil
+	}
+	split := make(map[string]errors.EdgeX)
+	for _, req := range reqs {
+		if err, ok := failures[req.DeviceResourceName]; ok {
+			split[req.DeviceResourceName] = err
+		}
+	}
+	return split
+}
@@ -0,0 +1,269 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// contextualDriverStub records the deadlines of the contexts its reads are given.
+type contextualDriverStub struct {
+	*mocks.ProtocolDriver
+	mutex     sync.Mutex
+	deadlines []time.Time
+	bounded   []bool
+}
+
+func (d *contextualDriverStub) HandleReadCommandsWithContext(ctx context.Context, deviceName string, protocols map[string]models.ProtocolProperties, reqs []sdkModels.CommandRequest) ([]*sdkModels.CommandValue, error) {
+	deadline, ok := ctx.Deadline()
+	d.mutex.Lock()
+	d.deadlines = append(d.deadlines, deadline)
+	d.bounded = append(d.bounded, ok)
+	d.mutex.Unlock()
+	return d.HandleReadCommands(deviceName, protocols, reqs)
+}
+
+func (d *contextualDriverStub) HandleWriteCommandsWithContext(_ context.Context, deviceName string, protocols map[string]models.ProtocolProperties, reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue) error {
+	return d.HandleWriteCommands(deviceName, protocols, reqs, params)
+}
+
+type coalescedResult struct {
+	values   []*sdkModels.CommandValue
+	failures map[string]errors.EdgeX
+	err      errors.EdgeX
+}
+
+// readConcurrently reads the given resources of testDevice concurrently, one caller per element of
+// resources, each with its own context.
+func readConcurrently(t *testing.T, contexts []context.Context, resources [][]string, configuration *config.ConfigurationStruct, driver *mocks.ProtocolDriver) []coalescedResult {
+	dic := mockDic(t, configuration, driver)
+	device := testDeviceModel(t, testDevice)
+
+	results := make([]coalescedResult, len(resources))
+	var wg sync.WaitGroup
+	for i := range resources {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values, failures, err := handleReadCommands(contexts[i], device, commandRequests(resources[i]...), 0, dic)
+			results[i] = coalescedResult{values: values, failures: failures, err: err}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+func coalescingConfig() *config.ConfigurationStruct {
+	return &config.ConfigurationStruct{
+		Device: config.DeviceInfo{
+			MaxCmdOps:      10,
+			ReadCoalescing: config.ReadCoalescingInfo{Enabled: true, Window: "50ms"},
+		},
+	}
+}
+
+func backgroundContexts(n int) []context.Context {
+	contexts := make([]context.Context, n)
+	for i := range contexts {
+		contexts[i] = context.Background()
+	}
+	return contexts
+}
+
+func TestCoalesceReadCommands(t *testing.T) {
+	driverValues := []*sdkModels.CommandValue{
+		stringValue(t, resource1, "v1"),
+		stringValue(t, resource2, "v2"),
+		stringValue(t, resource3, "v3"),
+	}
+	driverValues[0].Tags = map[string]string{"origin": "driver"}
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return(driverValues, nil)
+
+	resources := [][]string{{resource1}, {resource1, resource2}, {resource3, resource1}}
+	results := readConcurrently(t, backgroundContexts(len(resources)), resources, coalescingConfig(), driver)
+
+	// the reads are merged into one driver call reading every resource once
+	driver.AssertNumberOfCalls(t, "HandleReadCommands", 1)
+	reqs := driver.Calls[0].Arguments.Get(2).([]sdkModels.CommandRequest)
+	names := make([]string, len(reqs))
+	for i, req := range reqs {
+		names[i] = req.DeviceResourceName
+	}
+	assert.ElementsMatch(t, []string{resource1, resource2, resource3}, names)
+
+	// every caller gets the values it asked for, in its order
+	for i, result := range results {
+		require.NoError(t, result.err)
+		require.Len(t, result.values, len(resources[i]))
+		for j, cv := range result.values {
+			assert.Equal(t, resources[i][j], cv.DeviceResourceName)
+		}
+	}
+
+	// the values are copies owned by each caller
+	first, second := results[0].values[0], results[1].values[0]
+	assert.NotSame(t, driverValues[0], first)
+	assert.NotSame(t, first, second)
+	first.Value = "changed"
+	first.Tags["origin"] = "caller"
+	assert.Equal(t, "v1", second.Value)
+	assert.Equal(t, "driver", second.Tags["origin"])
+	assert.Equal(t, "driver", driverValues[0].Tags["origin"])
+}
+
+func TestCoalesceReadCommands_MaxCmdOps(t *testing.T) {
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{
+		stringValue(t, resource1, "v1"),
+		stringValue(t, resource2, "v2"),
+	}, nil)
+	configuration := coalescingConfig()
+	configuration.Device.MaxCmdOps = 1
+
+	resources := [][]string{{resource1}, {resource2}}
+	results := readConcurrently(t, backgroundContexts(len(resources)), resources, configuration, driver)
+
+	// the reads would exceed MaxCmdOps in a single driver call
+	driver.AssertNumberOfCalls(t, "HandleReadCommands", 2)
+	for i, result := range results {
+		require.NoError(t, result.err)
+		require.Len(t, result.values, 1)
+		assert.Equal(t, resources[i][0], result.values[0].DeviceResourceName)
+	}
+}
+
+func TestCoalesceReadCommands_Deadline(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeouts  []time.Duration
+		bounded   bool
+		latestIdx int
+	}{
+		{"latest deadline", []time.Duration{time.Second, 3 * time.Second, 2 * time.Second}, true, 1},
+		{"caller without deadline", []time.Duration{time.Second, 0}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := &contextualDriverStub{ProtocolDriver: &mocks.ProtocolDriver{}}
+			driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{stringValue(t, resource1, "v1")}, nil)
+			dic := mockDic(t, coalescingConfig(), driver)
+			device := testDeviceModel(t, testDevice)
+
+			contexts := make([]context.Context, len(tt.timeouts))
+			for i, timeout := range tt.timeouts {
+				if timeout == 0 {
+					contexts[i] = context.Background()
+					continue
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				defer cancel()
+				contexts[i] = ctx
+			}
+			var wg sync.WaitGroup
+			for _, ctx := range contexts {
+				wg.Add(1)
+				go func(ctx context.Context) {
+					defer wg.Done()
+					_, _, err := handleReadCommands(ctx, device, commandRequests(resource1), 0, dic)
+					assert.NoError(t, err)
+				}(ctx)
+			}
+			wg.Wait()
+
+			require.Len(t, driver.deadlines, 1)
+			assert.Equal(t, tt.bounded, driver.bounded[0])
+			if tt.bounded {
+				expected, _ := contexts[tt.latestIdx].Deadline()
+				assert.True(t, expected.Equal(driver.deadlines[0]), "the driver deadline %s is not the latest one %s", driver.deadlines[0], expected)
+			}
+		})
+	}
+}
+
+func TestCoalesceReadCommands_CallerCanceled(t *testing.T) {
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{stringValue(t, resource1, "v1")}, nil)
+
+	// the first caller gives up before the window elapses, the other one still gets its values
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	contexts := []context.Context{ctx, context.Background()}
+	results := readConcurrently(t, contexts, [][]string{{resource1}, {resource1}}, coalescingConfig(), driver)
+
+	require.Error(t, results[0].err)
+	assert.Equal(t, errors.KindServiceUnavailable, errors.Kind(results[0].err))
+	require.NoError(t, results[1].err)
+	require.Len(t, results[1].values, 1)
+}
+
+func TestCoalesceReadCommands_Errors(t *testing.T) {
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return(nil, stdErrors.New("no response"))
+
+	resources := [][]string{{resource1}, {resource2}}
+	results := readConcurrently(t, backgroundContexts(len(resources)), resources, coalescingConfig(), driver)
+
+	driver.AssertNumberOfCalls(t, "HandleReadCommands", 1)
+	for _, result := range results {
+		require.Error(t, result.err)
+		assert.Contains(t, result.err.Error(), "no response")
+		assert.Nil(t, result.values)
+	}
+}
+
+func TestCoalesceReadCommands_PartialRead(t *testing.T) {
+	partialErr := sdkModels.NewPartialReadError()
+	partialErr.Add(resource2, stdErrors.New("no response"))
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{
+		stringValue(t, resource1, "v1"),
+		stringValue(t, resource3, "v3"),
+	}, partialErr)
+	configuration := coalescingConfig()
+	configuration.Device.PartialReads = true
+
+	resources := [][]string{{resource1}, {resource2, resource3}}
+	results := readConcurrently(t, backgroundContexts(len(resources)), resources, configuration, driver)
+
+	driver.AssertNumberOfCalls(t, "HandleReadCommands", 1)
+	// the caller of the read resource gets no failure
+	require.NoError(t, results[0].err)
+	require.Len(t, results[0].values, 1)
+	assert.Empty(t, results[0].failures)
+
+	// the caller of the failed resource gets its failure along with its other values
+	require.NoError(t, results[1].err)
+	require.Len(t, results[1].values, 1)
+	assert.Equal(t, resource3, results[1].values[0].DeviceResourceName)
+	require.Len(t, results[1].failures, 1)
+	assert.Contains(t, results[1].failures[resource2].Error(), "no response")
+}
+
+func TestSplitReadFailures(t *testing.T) {
+	failures := map[string]errors.EdgeX{
+		resource1: errors.NewCommonEdgeX(errors.KindServerError, "failed", nil),
+		resource2: errors.NewCommonEdgeX(errors.KindServerError, "failed", nil),
+	}
+	assert.Nil(t, splitReadFailures(commandRequests(resource1), nil))
+	assert.Equal(t, map[string]errors.EdgeX{resource1: failures[resource1]}, splitReadFailures(commandRequests(resource1, resource3), failures))
+	assert.Empty(t, splitReadFailures(commandRequests(resource3), failures))
+}
@@ -16,6 +16,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/http/utils"
 
@@ -33,78 +34,144 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 )
 
-func GetCommand(ctx context.Context, deviceName string, commandName string, queryParams string, regexCmd bool, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+// GetCommand reads the device command, or device resource, of the device and returns the Event of the values
+// read. If the PartialReads setting is enabled, the Event lacks the readings of the resources which failed to
+// be read and the ResourceErrors of these are returned.
+func GetCommand(ctx context.Context, deviceName string, commandName string, queryParams string, regexCmd bool, maxAge time.Duration, units string, dic *di.Container) (*dtos.Event, []ResourceError, errors.EdgeX) {
 	if deviceName == "" {
-		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name is empty", nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name is empty", nil)
 	}
 	if commandName == "" {
-		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "command is empty", nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "command is empty", nil)
+	}
+	if err := transformer.ValidateUnits(units); err != nil {
+		return nil, nil, errors.NewCommonEdgeXWrapper(err)
 	}
 
 	device, err := validateServiceAndDeviceState(deviceName, dic)
 	if err != nil {
-		return nil, errors.NewCommonEdgeXWrapper(err)
+		return nil, nil, errors.NewCommonEdgeXWrapper(err)
 	}
 
+	ctx, cancel := commandContext(ctx, deviceName, commandName, dic)
+	defer cancel()
+
 	var res *dtos.Event
+	var resourceErrors []ResourceError
 	_, cmdExist := cache.Profiles().DeviceCommand(device.ProfileName, commandName)
 	if cmdExist {
-		res, err = readDeviceCommand(device, commandName, queryParams, dic)
+		res, resourceErrors, err = readDeviceCommand(ctx, device, commandName, queryParams, maxAge, units, dic)
 	} else if regexCmd {
-		res, err = readDeviceResourcesRegex(device, commandName, queryParams, dic)
+		res, resourceErrors, err = readDeviceResourcesRegex(ctx, device, commandName, queryParams, maxAge, units, dic)
 	} else {
-		res, err = readDeviceResource(device, commandName, queryParams, dic)
+		res, resourceErrors, err = readDeviceResource(ctx, device, commandName, queryParams, maxAge, units, dic)
 	}
 
 	if err != nil {
-		return nil, errors.NewCommonEdgeXWrapper(err)
+		return nil, nil, errors.NewCommonEdgeXWrapper(err)
 	}
 
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	lc.Debugf("GET Device Command successfully. Device: %s, Source: %s, %s: %s", deviceName, commandName, common.CorrelationHeader, utils.FromContext(ctx, common.CorrelationHeader))
-	return res, nil
+	return res, resourceErrors, nil
+}
+
+// SetCommandOptions are the options of a SET command given by its reserved query parameters.
+type SetCommandOptions struct {
+	// Units are the units of the written numeric values, see the ds-units query parameter
+	Units string
+	// Verify requests the written values to be read back and compared, see the ds-verify query parameter
+	Verify bool
+	// Transactional requests the previous values to be written back if the write or its verification
+	// fails, see the ds-transactional query parameter
+	Transactional bool
+	// DryRun requests the values to be validated and transformed but not written, see the ds-dryrun query parameter
+	DryRun bool
+	// ArmToken is the token of an armed command requiring select-before-operate, see the ds-armtoken query parameter
+	ArmToken string
+}
+
+// SetCommandResult is the result of a SET command, only one of its fields is set.
+type SetCommandResult struct {
+	// Event is the Event of the written values to be published, unless they are write-only
+	Event *dtos.Event
+	// DryRunValues are the transformed values which a dry run would pass to the ProtocolDriver
+	DryRunValues []*sdkModels.CommandValue
+	// Arming is the token of the command armed by the SET command, which did not write the values
+	Arming *ArmingToken
 }
 
-func SetCommand(ctx context.Context, deviceName string, commandName string, queryParams string, requests map[string]any, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+// SetCommand writes the requested values to the device resources of the given device command or device
+// resource. A dry run only validates and transforms the values, as does the SET command arming a command
+// requiring select-before-operate, which is only operated by a SET command presenting the arming token.
+func SetCommand(ctx context.Context, deviceName string, commandName string, queryParams string, options SetCommandOptions, requests map[string]any, dic *di.Container) (SetCommandResult, errors.EdgeX) {
 	if deviceName == "" {
-		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name is empty", nil)
+		return SetCommandResult{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name is empty", nil)
 	}
 	if commandName == "" {
-		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "command is empty", nil)
+		return SetCommandResult{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "command is empty", nil)
+	}
+	if err := transformer.ValidateUnits(options.Units); err != nil {
+		return SetCommandResult{}, errors.NewCommonEdgeXWrapper(err)
 	}
 
 	device, err := validateServiceAndDeviceState(deviceName, dic)
 	if err != nil {
-		return nil, errors.NewCommonEdgeXWrapper(err)
+		return SetCommandResult{}, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	ctx, cancel := commandContext(ctx, deviceName, commandName, dic)
+	defer cancel()
+
+	// the SET command arming a command only validates the values, as a dry run
+	arming := !options.DryRun && options.ArmToken == "" && armingRequired(device, commandName)
+	if arming {
+		options.DryRun = true
+	} else if !options.DryRun && options.ArmToken != "" {
+		if err = operateCommand(ctx, deviceName, commandName, options.ArmToken, requests, dic); err != nil {
+			return SetCommandResult{}, errors.NewCommonEdgeXWrapper(err)
+		}
 	}
 
 	var event *dtos.Event
+	var values []*sdkModels.CommandValue
 	_, cmdExist := cache.Profiles().DeviceCommand(device.ProfileName, commandName)
 	if cmdExist {
-		event, err = writeDeviceCommand(device, commandName, queryParams, requests, dic)
+		event, values, err = writeDeviceCommand(ctx, device, commandName, queryParams, options, requests, dic)
 	} else {
-		event, err = writeDeviceResource(device, commandName, queryParams, requests, dic)
+		event, values, err = writeDeviceResource(ctx, device, commandName, queryParams, options, requests, dic)
 	}
 
 	if err != nil {
-		return nil, errors.NewCommonEdgeXWrapper(err)
+		return SetCommandResult{}, errors.NewCommonEdgeXWrapper(err)
 	}
 
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	if arming {
+		token, err := armCommand(ctx, deviceName, commandName, requests, dic)
+		if err != nil {
+			return SetCommandResult{}, errors.NewCommonEdgeXWrapper(err)
+		}
+		return SetCommandResult{Arming: &token}, nil
+	}
+	if options.DryRun {
+		lc.Debugf("SET Device Command dry run successfully. Device: %s, Source: %s, %s: %s", deviceName, commandName, common.CorrelationHeader, utils.FromContext(ctx, common.CorrelationHeader))
+		return SetCommandResult{DryRunValues: values}, nil
+	}
 	lc.Debugf("SET Device Command successfully. Device: %s, Source: %s, %s: %s", deviceName, commandName, common.CorrelationHeader, utils.FromContext(ctx, common.CorrelationHeader))
-	return event, nil
+	return SetCommandResult{Event: event}, nil
 }
 
-func readDeviceResource(device models.Device, resourceName string, attributes string, dic *di.Container) (res *dtos.Event, edgexErr errors.EdgeX) {
+func readDeviceResource(ctx context.Context, device models.Device, resourceName string, attributes string, maxAge time.Duration, units string, dic *di.Container) (res *dtos.Event, resourceErrors []ResourceError, edgexErr errors.EdgeX) {
 	dr, ok := cache.Profiles().DeviceResource(device.ProfileName, resourceName)
 	if !ok {
 		errMsg := fmt.Sprintf("DeviceResource %s not found", resourceName)
-		return res, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+		return res, nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
 	}
 	// check deviceResource is not write-only
 	if dr.Properties.ReadWrite == common.ReadWrite_W {
 		errMsg := fmt.Sprintf("DeviceResource %s is marked as write-only", dr.Name)
-		return res, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
+		return res, nil, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
 	}
 
 	var req sdkModels.CommandRequest
@@ -123,28 +190,26 @@ func readDeviceResource(device models.Device, resourceName string, attributes st
 	reqs = append(reqs, req)
 
 	// execute protocol-specific read operation
-	driver := container.ProtocolDriverFrom(dic.Get)
-	results, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	results, failures, err := handleReadCommands(ctx, device, reqs, maxAge, dic)
 	if err != nil {
 		errMsg := fmt.Sprintf("error reading DeviceResource %s for %s", dr.Name, device.Name)
-		return res, errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return res, nil, errors.NewCommonEdgeX(errors.Kind(err), errMsg, err)
 	}
 
 	// convert CommandValue to Event
-	configuration := container.ConfigurationFrom(dic.Get)
-	res, edgexErr = transformer.CommandValuesToEventDTO(results, device.Name, dr.Name, configuration.Device.DataTransform, dic)
+	res, resourceErrors, edgexErr = readResultsToEvent(results, failures, reqs, device.Name, dr.Name, units, dic)
 	if edgexErr != nil {
-		return res, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert CommandValue to Event", err)
+		return res, nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert CommandValue to Event", edgexErr)
 	}
 
-	return res, nil
+	return res, resourceErrors, nil
 }
 
-func readDeviceResourcesRegex(device models.Device, regexResourceName string, attributes string, dic *di.Container) (res *dtos.Event, edgexErr errors.EdgeX) {
+func readDeviceResourcesRegex(ctx context.Context, device models.Device, regexResourceName string, attributes string, maxAge time.Duration, units string, dic *di.Container) (res *dtos.Event, resourceErrors []ResourceError, edgexErr errors.EdgeX) {
 	deviceResources, ok := cache.Profiles().DeviceResourcesByRegex(device.ProfileName, regexResourceName)
 	if !ok || len(deviceResources) == 0 {
 		errMsg := fmt.Sprintf("Regex DeviceResource %s not found", regexResourceName)
-		return res, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+		return res, nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
 	}
 
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
@@ -173,43 +238,41 @@ func readDeviceResourcesRegex(device models.Device, regexResourceName string, at
 
 	if len(reqs) == 0 {
 		errMsg := fmt.Sprintf("no readable resources matched with %s", regexResourceName)
-		return res, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
+		return res, nil, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
 	}
 
 	// execute protocol-specific read operation
-	driver := container.ProtocolDriverFrom(dic.Get)
-	results, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	results, failures, err := handleReadCommands(ctx, device, reqs, maxAge, dic)
 	if err != nil {
 		errMsg := fmt.Sprintf("error reading Regex DeviceResource(s) %s for %s", regexResourceName, device.Name)
-		return res, errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return res, nil, errors.NewCommonEdgeX(errors.Kind(err), errMsg, err)
 	}
 
 	// convert CommandValue to Event
-	configuration := container.ConfigurationFrom(dic.Get)
-	res, edgexErr = transformer.CommandValuesToEventDTO(results, device.Name, regexResourceName, configuration.Device.DataTransform, dic)
+	res, resourceErrors, edgexErr = readResultsToEvent(results, failures, reqs, device.Name, regexResourceName, units, dic)
 	if edgexErr != nil {
-		return res, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert CommandValue to Event", err)
+		return res, nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert CommandValue to Event", edgexErr)
 	}
 
-	return res, nil
+	return res, resourceErrors, nil
 }
 
-func readDeviceCommand(device models.Device, commandName string, attributes string, dic *di.Container) (res *dtos.Event, edgexErr errors.EdgeX) {
+func readDeviceCommand(ctx context.Context, device models.Device, commandName string, attributes string, maxAge time.Duration, units string, dic *di.Container) (res *dtos.Event, resourceErrors []ResourceError, edgexErr errors.EdgeX) {
 	dc, ok := cache.Profiles().DeviceCommand(device.ProfileName, commandName)
 	if !ok {
 		errMsg := fmt.Sprintf("DeviceCommand %s not found", commandName)
-		return res, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+		return res, nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
 	}
 	// check deviceCommand is not write-only
 	if dc.ReadWrite == common.ReadWrite_W {
 		errMsg := fmt.Sprintf("DeviceCommand %s is marked as write-only", dc.Name)
-		return res, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
+		return res, nil, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
 	}
 	// check ResourceOperation count does not exceed MaxCmdOps defined in configuration
 	configuration := container.ConfigurationFrom(dic.Get)
 	if len(dc.ResourceOperations) > configuration.Device.MaxCmdOps {
 		errMsg := fmt.Sprintf("GET command %s exceed device %s MaxCmdOps (%d)", dc.Name, device.Name, configuration.Device.MaxCmdOps)
-		return res, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+		return res, nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
 	}
 
 	// prepare CommandRequests
@@ -220,7 +283,7 @@ func readDeviceCommand(device models.Device, commandName string, attributes stri
 		dr, ok := cache.Profiles().DeviceResource(device.ProfileName, drName)
 		if !ok {
 			errMsg := fmt.Sprintf("DeviceResource %s in GET commnd %s for %s not defined", drName, dc.Name, device.Name)
-			return res, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+			return res, nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
 		}
 
 		reqs[i].DeviceResourceName = dr.Name
@@ -235,32 +298,31 @@ func readDeviceCommand(device models.Device, commandName string, attributes stri
 	}
 
 	// execute protocol-specific read operation
-	driver := container.ProtocolDriverFrom(dic.Get)
-	results, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	results, failures, err := handleReadCommands(ctx, device, reqs, maxAge, dic)
 	if err != nil {
 		errMsg := fmt.Sprintf("error reading DeviceCommand %s for %s", dc.Name, device.Name)
-		return res, errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return res, nil, errors.NewCommonEdgeX(errors.Kind(err), errMsg, err)
 	}
 
 	// convert CommandValue to Event
-	res, edgexErr = transformer.CommandValuesToEventDTO(results, device.Name, dc.Name, configuration.Device.DataTransform, dic)
+	res, resourceErrors, edgexErr = readResultsToEvent(results, failures, reqs, device.Name, dc.Name, units, dic)
 	if edgexErr != nil {
-		return res, errors.NewCommonEdgeX(errors.KindServerError, "failed to transform CommandValue to Event", edgexErr)
+		return res, nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to transform CommandValue to Event", edgexErr)
 	}
 
-	return res, nil
+	return res, resourceErrors, nil
 }
 
-func writeDeviceResource(device models.Device, resourceName string, attributes string, requests map[string]any, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+func writeDeviceResource(ctx context.Context, device models.Device, resourceName string, attributes string, options SetCommandOptions, requests map[string]any, dic *di.Container) (*dtos.Event, []*sdkModels.CommandValue, errors.EdgeX) {
 	dr, ok := cache.Profiles().DeviceResource(device.ProfileName, resourceName)
 	if !ok {
 		errMsg := fmt.Sprintf("DeviceResource %s not found", resourceName)
-		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
 	}
 	// check deviceResource is not read-only
 	if dr.Properties.ReadWrite == common.ReadWrite_R {
 		errMsg := fmt.Sprintf("DeviceResource %s is marked as read-only", dr.Name)
-		return nil, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
 	}
 
 	// check set parameters contains provided deviceResource
@@ -270,14 +332,27 @@ func writeDeviceResource(device models.Device, resourceName string, attributes s
 			v = dr.Properties.DefaultValue
 		} else {
 			errMsg := fmt.Sprintf("DeviceResource %s not found in request body and no default value defined", dr.Name)
-			return nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+			return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
 		}
 	}
 
 	// create CommandValue
-	cv, edgexErr := createCommandValueFromDeviceResource(dr, v)
+	configuration := container.ConfigurationFrom(dic.Get)
+	units := ""
+	if configuration.Device.DataTransform {
+		units = transformer.TargetUnits(device, dr, options.Units)
+	}
+	cv, edgexErr := createCommandValueFromDeviceResource(dr, v, units, configuration.Device.MaxCmdValueLen)
 	if edgexErr != nil {
-		return nil, errors.NewCommonEdgeX(errors.Kind(edgexErr), "failed to create CommandValue", edgexErr)
+		return nil, nil, errors.NewCommonEdgeX(errors.Kind(edgexErr), "failed to create CommandValue", edgexErr)
+	}
+
+	// transform write value
+	if configuration.Device.DataTransform {
+		edgexErr = transformer.TransformWriteParameter(cv, dr)
+		if edgexErr != nil {
+			return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", edgexErr)
+		}
 	}
 
 	// prepare CommandRequest
@@ -292,47 +367,57 @@ func writeDeviceResource(device models.Device, resourceName string, attributes s
 	}
 	reqs[0].Type = cv.Type
 
-	// transform write value
-	configuration := container.ConfigurationFrom(dic.Get)
-	if configuration.Device.DataTransform {
-		edgexErr = transformer.TransformWriteParameter(cv, dr.Properties)
-		if edgexErr != nil {
-			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", edgexErr)
-		}
+	// return the value which would be written if the write is a dry run
+	if options.DryRun {
+		return nil, []*sdkModels.CommandValue{cv}, nil
+	}
+
+	// snapshot the current value if the write is transactional
+	snapshot, edgexErr := snapshotWrite(ctx, device, reqs, options.Transactional, dic)
+	if edgexErr != nil {
+		errMsg := fmt.Sprintf("error reading the current value of DeviceResource %s for %s", dr.Name, device.Name)
+		return nil, nil, errors.NewCommonEdgeX(errors.Kind(edgexErr), errMsg, edgexErr)
 	}
 
 	// execute protocol-specific write operation
-	driver := container.ProtocolDriverFrom(dic.Get)
-	err := driver.HandleWriteCommands(device.Name, device.Protocols, reqs, []*sdkModels.CommandValue{cv})
-	if err != nil {
+	edgexErr = handleWriteCommands(ctx, device, reqs, []*sdkModels.CommandValue{cv}, dic)
+	if edgexErr != nil {
 		errMsg := fmt.Sprintf("error writing DeviceResource %s for %s", dr.Name, device.Name)
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return nil, nil, rollbackWrite(device, resourceName, reqs, snapshot, errors.NewCommonEdgeX(errors.Kind(edgexErr), errMsg, edgexErr), dic)
+	}
+
+	// read back the written value if requested
+	edgexErr = verifyWrite(ctx, device, reqs, []*sdkModels.CommandValue{cv}, options.Verify, dic)
+	if edgexErr != nil {
+		errMsg := fmt.Sprintf("error verifying DeviceResource %s for %s", dr.Name, device.Name)
+		return nil, nil, rollbackWrite(device, resourceName, reqs, snapshot, errors.NewCommonEdgeX(errors.Kind(edgexErr), errMsg, edgexErr), dic)
 	}
 
 	// Updated resource value will be published to MessageBus as long as it's not write-only
 	if dr.Properties.ReadWrite != common.ReadWrite_W {
-		return transformer.CommandValuesToEventDTO([]*sdkModels.CommandValue{cv}, device.Name, resourceName, false, dic)
+		event, edgexErr := transformer.CommandValuesToEventDTO([]*sdkModels.CommandValue{cv}, device.Name, resourceName, false, "", dic)
+		return event, nil, edgexErr
 	}
 
-	return nil, nil
+	return nil, nil, nil
 }
 
-func writeDeviceCommand(device models.Device, commandName string, attributes string, requests map[string]any, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+func writeDeviceCommand(ctx context.Context, device models.Device, commandName string, attributes string, options SetCommandOptions, requests map[string]any, dic *di.Container) (*dtos.Event, []*sdkModels.CommandValue, errors.EdgeX) {
 	dc, ok := cache.Profiles().DeviceCommand(device.ProfileName, commandName)
 	if !ok {
 		errMsg := fmt.Sprintf("DeviceCommand %s not found", commandName)
-		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
 	}
 	// check deviceCommand is not read-only
 	if dc.ReadWrite == common.ReadWrite_R {
 		errMsg := fmt.Sprintf("DeviceCommand %s is marked as read-only", dc.Name)
-		return nil, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
 	}
 	// check ResourceOperation count does not exceed MaxCmdOps defined in configuration
 	configuration := container.ConfigurationFrom(dic.Get)
 	if len(dc.ResourceOperations) > configuration.Device.MaxCmdOps {
 		errMsg := fmt.Sprintf("SET command %s exceed device %s MaxCmdOps (%d)", dc.Name, device.Name, configuration.Device.MaxCmdOps)
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
 	}
 
 	// create CommandValues
@@ -343,7 +428,7 @@ func writeDeviceCommand(device models.Device, commandName string, attributes str
 		dr, ok := cache.Profiles().DeviceResource(device.ProfileName, drName)
 		if !ok {
 			errMsg := fmt.Sprintf("DeviceResource %s in SET commnd %s for %s not defined", drName, dc.Name, device.Name)
-			return nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+			return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
 		}
 
 		// check request body contains the deviceResource
@@ -355,7 +440,7 @@ func writeDeviceCommand(device models.Device, commandName string, attributes str
 				value = dr.Properties.DefaultValue
 			} else {
 				errMsg := fmt.Sprintf("DeviceResource %s not found in request body and no default value defined", dr.Name)
-				return nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+				return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
 			}
 		}
 
@@ -371,11 +456,15 @@ func writeDeviceCommand(device models.Device, commandName string, attributes str
 		}
 
 		// create CommandValue
-		cv, err := createCommandValueFromDeviceResource(dr, value)
+		units := ""
+		if configuration.Device.DataTransform {
+			units = transformer.TargetUnits(device, dr, options.Units)
+		}
+		cv, err := createCommandValueFromDeviceResource(dr, value, units, configuration.Device.MaxCmdValueLen)
 		if err == nil {
 			cvs = append(cvs, cv)
 		} else {
-			return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to create CommandValue", err)
+			return nil, nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to create CommandValue", err)
 		}
 	}
 
@@ -392,31 +481,50 @@ func writeDeviceCommand(device models.Device, commandName string, attributes str
 			}
 			reqs[i].Attributes[sdkCommon.URLRawQuery] = attributes
 		}
-		reqs[i].Type = cv.Type
 
 		// transform write value
 		if configuration.Device.DataTransform {
-			err := transformer.TransformWriteParameter(cv, dr.Properties)
+			err := transformer.TransformWriteParameter(cv, dr)
 			if err != nil {
-				return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", err)
+				return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", err)
 			}
 		}
+		reqs[i].Type = cv.Type
+	}
+
+	// return the values which would be written if the write is a dry run
+	if options.DryRun {
+		return nil, cvs, nil
+	}
+
+	// snapshot the current values if the write is transactional
+	snapshot, edgexErr := snapshotWrite(ctx, device, reqs, options.Transactional, dic)
+	if edgexErr != nil {
+		errMsg := fmt.Sprintf("error reading the current values of DeviceCommand %s for %s", dc.Name, device.Name)
+		return nil, nil, errors.NewCommonEdgeX(errors.Kind(edgexErr), errMsg, edgexErr)
 	}
 
 	// execute protocol-specific write operation
-	driver := container.ProtocolDriverFrom(dic.Get)
-	err := driver.HandleWriteCommands(device.Name, device.Protocols, reqs, cvs)
-	if err != nil {
+	edgexErr = handleWriteCommands(ctx, device, reqs, cvs, dic)
+	if edgexErr != nil {
 		errMsg := fmt.Sprintf("error writing DeviceCommand %s for %s", dc.Name, device.Name)
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return nil, nil, rollbackWrite(device, commandName, reqs, snapshot, errors.NewCommonEdgeX(errors.Kind(edgexErr), errMsg, edgexErr), dic)
+	}
+
+	// read back the written values if requested
+	edgexErr = verifyWrite(ctx, device, reqs, cvs, options.Verify, dic)
+	if edgexErr != nil {
+		errMsg := fmt.Sprintf("error verifying DeviceCommand %s for %s", dc.Name, device.Name)
+		return nil, nil, rollbackWrite(device, commandName, reqs, snapshot, errors.NewCommonEdgeX(errors.Kind(edgexErr), errMsg, edgexErr), dic)
 	}
 
 	// Updated resource(s) value will be published to MessageBus as long as they're not write-only
 	if dc.ReadWrite != common.ReadWrite_W {
-		return transformer.CommandValuesToEventDTO(cvs, device.Name, commandName, false, dic)
+		event, edgexErr := transformer.CommandValuesToEventDTO(cvs, device.Name, commandName, false, "", dic)
+		return event, nil, edgexErr
 	}
 
-	return nil, nil
+	return nil, nil, nil
 }
 
 func validateServiceAndDeviceState(deviceName string, dic *di.Container) (models.Device, errors.EdgeX) {
@@ -444,7 +552,10 @@ func validateServiceAndDeviceState(deviceName string, dic *di.Container) (models
 	return device, nil
 }
 
-func createCommandValueFromDeviceResource(dr models.DeviceResource, value interface{}) (*sdkModels.CommandValue, errors.EdgeX) {
+// createCommandValueFromDeviceResource parses the value written to the device resource in the given units, if
+// not empty, converts it to the Units of the device resource and validates it against the write constraints,
+// so that all the constraints apply in the Units of the device resource.
+func createCommandValueFromDeviceResource(dr models.DeviceResource, value interface{}, units string, maxValueLen int) (*sdkModels.CommandValue, errors.EdgeX) {
 	var err error
 	var result *sdkModels.CommandValue
 
@@ -454,7 +565,7 @@ func createCommandValueFromDeviceResource(dr models.DeviceResource, value interf
 		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("empty string is invalid for %v value type", dr.Properties.ValueType), nil)
 	}
 
-	switch dr.Properties.ValueType {
+	switch transformer.WriteValueType(dr, units) {
 	case common.ValueTypeString:
 		result, err = sdkModels.NewCommandValue(dr.Name, common.ValueTypeString, v)
 	case common.ValueTypeStringArray:
@@ -701,6 +812,12 @@ func createCommandValueFromDeviceResource(dr models.DeviceResource, value interf
 	if err != nil {
 		return nil, errors.NewCommonEdgeXWrapper(err)
 	}
+	if edgexErr := transformer.ConvertWriteUnits(result, dr, units); edgexErr != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to convert set parameter units", edgexErr)
+	}
+	if edgexErr := transformer.ValidateWriteConstraints(result, dr, maxValueLen); edgexErr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgexErr)
+	}
 	return result, nil
 }
 
@@ -0,0 +1,77 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+)
+
+func TestCreateCommandValueFromDeviceResource_Units(t *testing.T) {
+	minimum := 0.0
+	kPa := models.DeviceResource{
+		Name: resource1,
+		Properties: models.ResourceProperties{
+			ValueType: common.ValueTypeFloat64,
+			Units:     "kPa",
+			Minimum:   &minimum,
+			Optional:  map[string]any{sdkCommon.Step: 50},
+		},
+	}
+	allowed := models.DeviceResource{
+		Name: resource1,
+		Properties: models.ResourceProperties{
+			ValueType: common.ValueTypeInt16,
+			Units:     "bar",
+			Optional:  map[string]any{sdkCommon.AllowedValues: "1,2"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		dr        models.DeviceResource
+		value     string
+		units     string
+		expectErr bool
+		expected  any
+	}{
+		{"step in the resource units", kPa, "14.503773773", "psi", false, 100.0},
+		{"step not met in the resource units", kPa, "10", "psi", true, nil},
+		{"step in the caller units not applied", kPa, "50", "psi", true, nil},
+		{"step without conversion", kPa, "50", "", false, 50.0},
+		{"allowed value in the resource units", allowed, "29", "psi", false, int16(2)},
+		{"allowed value in the caller units not applied", allowed, "2", "psi", true, nil},
+		{"decimal value of an integer resource", allowed, "14.5", "psi", false, int16(1)},
+		{"decimal value of an integer resource without conversion", allowed, "1.5", "", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := createCommandValueFromDeviceResource(tt.dr, tt.value, tt.units, 0)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if expected, ok := tt.expected.(float64); ok {
+				assert.InDelta(t, expected, cv.Value, 1e-6)
+			} else {
+				assert.Equal(t, tt.expected, cv.Value)
+			}
+		})
+	}
+
+	_, err := createCommandValueFromDeviceResource(kPa, "1", "m", 0)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+}
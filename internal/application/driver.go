@@ -0,0 +1,256 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// commandContext derives a context from ctx which is bounded by the CommandTimeout configured
+// for the given device and command. The returned CancelFunc must always be called.
+func commandContext(ctx context.Context, deviceName string, commandName string, dic *di.Container) (context.Context, context.CancelFunc) {
+	timeout := commandTimeout(deviceName, commandName, dic)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// commandTimeout returns the timeout of the device command, the command specific setting takes
+// precedence over the device specific setting which takes precedence over the default one.
+func commandTimeout(deviceName string, commandName string, dic *di.Container) time.Duration {
+	timeoutInfo := container.ConfigurationFrom(dic.Get).Device.CommandTimeout
+
+	timeout := timeoutInfo.Default
+	if t, ok := timeoutInfo.Devices[deviceName]; ok {
+		timeout = t
+	}
+	if t, ok := timeoutInfo.Commands[commandName]; ok {
+		timeout = t
+	}
+	if timeout == "" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Warnf("failed to parse CommandTimeout '%s' of command %s for device %s, no timeout applied: %v", timeout, commandName, deviceName, err)
+		return 0
+	}
+	return duration
+}
+
+// handleReadCommands executes the protocol-specific read operation and gives up as soon as ctx is done.
+// The cached values are returned instead if all of them were read no longer than maxAge ago, and the
+// reads are merged with the concurrent reads of the same device if read coalescing is enabled. The errors
+// of the resources which failed to be read by a partial read are returned keyed by DeviceResourceName.
+func handleReadCommands(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, maxAge time.Duration, dic *di.Container) ([]*sdkModels.CommandValue, map[string]errors.EdgeX, errors.EdgeX) {
+	if results, ok := cachedReadResults(device.Name, reqs, maxAge); ok {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Debugf("Read %d resource(s) of device %s from cache", len(results), device.Name)
+		return results, nil, nil
+	}
+	if window, ok := readCoalescingWindow(reqs, dic); ok {
+		return coalesceReadCommands(ctx, device, reqs, window, dic)
+	}
+	return driverReadCommands(ctx, device, reqs, dic)
+}
+
+// driverReadCommands calls the ProtocolDriver to read the given resources once the AccessLimits of the
+// device allow it, retries the call according to the Retry policy, and records the final outcome for the
+// circuit breaker of the device. A partial read is neither retried nor recorded as a failure.
+func driverReadCommands(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, dic *di.Container) ([]*sdkModels.CommandValue, map[string]errors.EdgeX, errors.EdgeX) {
+	var results []*sdkModels.CommandValue
+	var failures map[string]errors.EdgeX
+	driverCalled, err := withRetry(ctx, device, reqs, false, dic, func() (bool, errors.EdgeX) {
+		release, err := acquireDriverAccess(ctx, device, dic)
+		if err != nil {
+			return false, err
+		}
+		results, failures, err = callReadCommands(ctx, device, reqs, release, dic)
+		return true, err
+	})
+	if driverCalled {
+		recordDriverResult(ctx, device, err, dic)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cacheable(reqs) {
+		for _, cv := range results {
+			cache.Readings().Add(device.Name, cv)
+		}
+	}
+	return results, failures, nil
+}
+
+// handleWriteCommands executes the protocol-specific write operation once the AccessLimits of the device
+// allow it, retries the call according to the Retry policy if RetryWrites is enabled, and records the final
+// outcome for the circuit breaker of the device. The cached readings of the written resources are removed
+// once the write succeeds, so that they are read again from the device.
+func handleWriteCommands(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue, dic *di.Container) errors.EdgeX {
+	driverCalled, err := withRetry(ctx, device, reqs, true, dic, func() (bool, errors.EdgeX) {
+		release, err := acquireDriverAccess(ctx, device, dic)
+		if err != nil {
+			return false, err
+		}
+		return true, callWriteCommands(ctx, device, reqs, params, release, dic)
+	})
+	if driverCalled {
+		recordDriverResult(ctx, device, err, dic)
+	}
+	if err == nil {
+		resourceNames := make([]string, len(reqs))
+		for i, req := range reqs {
+			resourceNames[i] = req.DeviceResourceName
+		}
+		cache.Readings().RemoveByResourceNames(device.Name, resourceNames...)
+	}
+	return err
+}
+
+// callReadCommands calls the ProtocolDriver read operation and gives up as soon as ctx is done. The ctx is
+// passed to the driver if it implements interfaces.ContextualProtocolDriver, otherwise the driver call is
+// left running in the background when ctx is done before it returns. The release function is called once
+// the driver has returned. If the PartialReads setting is enabled, the values of a *sdkModels.PartialReadError
+// are returned along with the errors of the failed resources, unless none of the resources was read.
+func callReadCommands(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, release func(), dic *di.Container) ([]*sdkModels.CommandValue, map[string]errors.EdgeX, errors.EdgeX) {
+	driver := container.ProtocolDriverFrom(dic.Get)
+
+	var results []*sdkModels.CommandValue
+	var err error
+	if contextualDriver, ok := driver.(interfaces.ContextualProtocolDriver); ok {
+		results, err = contextualDriver.HandleReadCommandsWithContext(ctx, device.Name, device.Protocols, reqs)
+		release()
+	} else if ctx.Done() == nil {
+		results, err = driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+		release()
+	} else {
+		type readResult struct {
+			values []*sdkModels.CommandValue
+			err    error
+		}
+		done := make(chan readResult, 1)
+		go func() {
+			// the access is held until the driver returns even if the caller has given up
+			defer release()
+			values, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+			done <- readResult{values: values, err: err}
+		}()
+		select {
+		case <-ctx.Done():
+			return nil, nil, contextError(ctx, device.Name)
+		case res := <-done:
+			results, err = res.values, res.err
+		}
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, contextError(ctx, device.Name)
+		}
+		var partialErr *sdkModels.PartialReadError
+		if stdErrors.As(err, &partialErr) && len(results) > 0 && container.ConfigurationFrom(dic.Get).Device.PartialReads {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			lc.Warnf("Partial read of device %s: %v", device.Name, partialErr)
+			return results, partialReadFailures(partialErr), nil
+		}
+		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, "", err)
+	}
+	return results, nil, nil
+}
+
+// callWriteCommands calls the ProtocolDriver write operation the same way callReadCommands calls the read one.
+func callWriteCommands(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue, release func(), dic *di.Container) errors.EdgeX {
+	driver := container.ProtocolDriverFrom(dic.Get)
+
+	var err error
+	if contextualDriver, ok := driver.(interfaces.ContextualProtocolDriver); ok {
+		err = contextualDriver.HandleWriteCommandsWithContext(ctx, device.Name, device.Protocols, reqs, params)
+		release()
+	} else if ctx.Done() == nil {
+		err = driver.HandleWriteCommands(device.Name, device.Protocols, reqs, params)
+		release()
+	} else {
+		done := make(chan error, 1)
+		go func() {
+			defer release()
+			done <- driver.HandleWriteCommands(device.Name, device.Protocols, reqs, params)
+		}()
+		select {
+		case <-ctx.Done():
+			return contextError(ctx, device.Name)
+		case err = <-done:
+		}
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return contextError(ctx, device.Name)
+		}
+		return errors.NewCommonEdgeX(errors.KindServerError, "", err)
+	}
+	return nil
+}
+
+// cachedReadResults returns the cached values of all the requested resources flagged as stale, it
+// returns false if any of them is missing or older than maxAge.
+func cachedReadResults(deviceName string, reqs []sdkModels.CommandRequest, maxAge time.Duration) ([]*sdkModels.CommandValue, bool) {
+	if maxAge <= 0 || !cacheable(reqs) {
+		return nil, false
+	}
+
+	results := make([]*sdkModels.CommandValue, len(reqs))
+	for i, req := range reqs {
+		cv, ok := cache.Readings().ForName(deviceName, req.DeviceResourceName, maxAge)
+		if !ok {
+			return nil, false
+		}
+		// the cached values were not read by this read, unless they are already flagged as worse
+		if cv.Quality != sdkModels.QualityBad {
+			cv.Quality = sdkModels.QualityStale
+		}
+		results[i] = cv
+	}
+	return results, true
+}
+
+// cacheable checks whether the values of the reads can be shared with other reads, which is not
+// the case if the driver is given query parameters.
+func cacheable(reqs []sdkModels.CommandRequest) bool {
+	for _, req := range reqs {
+		if _, ok := req.Attributes[sdkCommon.URLRawQuery]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func contextError(ctx context.Context, deviceName string) errors.EdgeX {
+	if ctx.Err() == context.DeadlineExceeded {
+		errMsg := fmt.Sprintf("ProtocolDriver call for device %s timed out", deviceName)
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, errMsg, ctx.Err())
+	}
+	errMsg := fmt.Sprintf("ProtocolDriver call for device %s canceled", deviceName)
+	return errors.NewCommonEdgeX(errors.KindServiceUnavailable, errMsg, ctx.Err())
+}
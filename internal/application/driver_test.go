@@ -0,0 +1,219 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+const (
+	testService = "test-service"
+	testProfile = "test-profile"
+
+	testDevice  = "test-device"
+	otherDevice = "other-device"
+
+	testProtocol = "modbus-tcp"
+	testAddress  = "10.0.0.1"
+
+	resource1 = "resource1"
+	resource2 = "resource2"
+	resource3 = "resource3"
+)
+
+// autoEventManagerStub records the devices whose AutoEvents are stopped and restarted.
+type autoEventManagerStub struct {
+	mutex     sync.Mutex
+	stopped   []string
+	restarted []string
+}
+
+func (m *autoEventManagerStub) StartAutoEvents() {}
+
+func (m *autoEventManagerStub) RestartForDevice(name string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.restarted = append(m.restarted, name)
+}
+
+func (m *autoEventManagerStub) StopForDevice(name string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.stopped = append(m.stopped, name)
+}
+
+func (m *autoEventManagerStub) AutoEventStatuses(string) ([]sdkModels.AutoEventStatus, errors.EdgeX) {
+	return nil, nil
+}
+
+func (m *autoEventManagerStub) PauseAutoEvent(string, string) errors.EdgeX { return nil }
+
+func (m *autoEventManagerStub) ResumeAutoEvent(string, string) errors.EdgeX { return nil }
+
+func (m *autoEventManagerStub) TriggerAutoEvent(string, string) errors.EdgeX { return nil }
+
+func (m *autoEventManagerStub) calls() (stopped []string, restarted []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]string(nil), m.stopped...), append([]string(nil), m.restarted...)
+}
+
+// mockDic initializes the caches with testDevice and otherDevice, which share the address of testProtocol,
+// and returns a container holding the given configuration and ProtocolDriver.
+func mockDic(t *testing.T, configuration *config.ConfigurationStruct, driver interfaces.ProtocolDriver) *di.Container {
+	protocols := map[string]dtos.ProtocolProperties{testProtocol: {"Address": testAddress}}
+	devices := []dtos.Device{
+		{Name: testDevice, AdminState: models.Unlocked, OperatingState: models.Up, ServiceName: testService, ProfileName: testProfile, Protocols: protocols},
+		{Name: otherDevice, AdminState: models.Unlocked, OperatingState: models.Up, ServiceName: testService, ProfileName: testProfile, Protocols: protocols},
+	}
+	profile := dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: testProfile},
+	}
+	for _, name := range []string{resource1, resource2, resource3} {
+		profile.DeviceResources = append(profile.DeviceResources, dtos.DeviceResource{
+			Name:       name,
+			Properties: dtos.ResourceProperties{ValueType: common.ValueTypeString, ReadWrite: common.ReadWrite_RW},
+		})
+	}
+
+	mockDeviceClient := &clientMocks.DeviceClient{}
+	mockDeviceClient.On("DevicesByServiceName", context.Background(), testService, 0, -1).
+		Return(responses.NewMultiDevicesResponse("", "", http.StatusOK, uint32(len(devices)), devices), nil)
+	mockDeviceClient.On("Update", mock.Anything, mock.Anything).Return(nil, nil)
+	mockDeviceProfileClient := &clientMocks.DeviceProfileClient{}
+	mockDeviceProfileClient.On("DeviceProfileByName", context.Background(), testProfile).
+		Return(responses.NewDeviceProfileResponse("", "", http.StatusOK, profile), nil)
+	mockProvisionWatcherClient := &clientMocks.ProvisionWatcherClient{}
+	mockProvisionWatcherClient.On("ProvisionWatchersByServiceName", context.Background(), testService, 0, -1).
+		Return(responses.NewMultiProvisionWatchersResponse("", "", http.StatusOK, 0, nil), nil)
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) any {
+			return configuration
+		},
+		container.ProtocolDriverName: func(get di.Get) any {
+			return driver
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) any {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) any {
+			return mockDeviceClient
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) any {
+			return mockDeviceProfileClient
+		},
+		bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) any {
+			return mockProvisionWatcherClient
+		},
+	})
+	edgexErr := cache.InitCache(testService, dic)
+	require.NoError(t, edgexErr)
+	return dic
+}
+
+func testDeviceModel(t *testing.T, name string) models.Device {
+	device, ok := cache.Devices().ForName(name)
+	require.True(t, ok)
+	return device
+}
+
+func commandRequests(names ...string) []sdkModels.CommandRequest {
+	reqs := make([]sdkModels.CommandRequest, len(names))
+	for i, name := range names {
+		reqs[i] = sdkModels.CommandRequest{DeviceResourceName: name, Type: common.ValueTypeString}
+	}
+	return reqs
+}
+
+func stringValue(t *testing.T, name string, value string) *sdkModels.CommandValue {
+	cv, err := sdkModels.NewCommandValue(name, common.ValueTypeString, value)
+	require.NoError(t, err)
+	return cv
+}
+
+func TestCachedReadResults(t *testing.T) {
+	mockDic(t, &config.ConfigurationStruct{}, &mocks.ProtocolDriver{})
+	t.Cleanup(func() {
+		cache.Readings().RemoveByDeviceName(testDevice)
+	})
+	good := stringValue(t, resource1, "v1")
+	good.Quality = sdkModels.QualityGood
+	bad := stringValue(t, resource2, "v2")
+	bad.Quality = sdkModels.QualityBad
+	cache.Readings().Add(testDevice, good)
+	cache.Readings().Add(testDevice, bad)
+
+	// the cached values are flagged as stale, unless they are bad
+	results, ok := cachedReadResults(testDevice, commandRequests(resource1, resource2), time.Minute)
+	require.True(t, ok)
+	require.Len(t, results, 2)
+	assert.Equal(t, sdkModels.QualityStale, results[0].Quality)
+	assert.Equal(t, sdkModels.QualityBad, results[1].Quality)
+	assert.Equal(t, sdkModels.QualityGood, good.Quality)
+
+	// all the values must be cached and young enough
+	_, ok = cachedReadResults(testDevice, commandRequests(resource1, resource3), time.Minute)
+	assert.False(t, ok)
+	_, ok = cachedReadResults(testDevice, commandRequests(resource1), 0)
+	assert.False(t, ok)
+	time.Sleep(5 * time.Millisecond)
+	_, ok = cachedReadResults(testDevice, commandRequests(resource1), time.Millisecond)
+	assert.False(t, ok)
+}
+
+func TestHandleWriteCommands_RemovesCachedReadings(t *testing.T) {
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{stringValue(t, resource1, "before")}, nil).Once()
+	driver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{stringValue(t, resource1, "after")}, nil).Once()
+	driver.On("HandleWriteCommands", testDevice, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	dic := mockDic(t, &config.ConfigurationStruct{}, driver)
+	t.Cleanup(func() {
+		cache.Readings().RemoveByDeviceName(testDevice)
+	})
+	device, ok := cache.Devices().ForName(testDevice)
+	require.True(t, ok)
+
+	values, _, err := handleReadCommands(context.Background(), device, commandRequests(resource1), time.Minute, dic)
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.Equal(t, "before", values[0].Value)
+
+	err = handleWriteCommands(context.Background(), device, commandRequests(resource1), []*sdkModels.CommandValue{stringValue(t, resource1, "after")}, dic)
+	require.NoError(t, err)
+
+	// the value read before the write is no longer served from the cache
+	values, _, err = handleReadCommands(context.Background(), device, commandRequests(resource1), time.Minute, dic)
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.Equal(t, "after", values[0].Value)
+	driver.AssertNumberOfCalls(t, "HandleReadCommands", 2)
+}
@@ -0,0 +1,43 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// DryRunValue is a value which a dry run of a SET command would pass to the ProtocolDriver, i.e. after the
+// mappings have been reversed and the write transforms applied.
+type DryRunValue struct {
+	DeviceResourceName string `json:"deviceResourceName"`
+	ValueType          string `json:"valueType"`
+	Value              any    `json:"value"`
+}
+
+// DryRunResponse is the response of a dry run of a SET command, listing the values which would be written.
+type DryRunResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Values                 []DryRunValue `json:"values"`
+}
+
+// NewDryRunResponse creates a DryRunResponse with the given CommandValues.
+func NewDryRunResponse(requestId string, statusCode int, values []*sdkModels.CommandValue) DryRunResponse {
+	dryRunValues := make([]DryRunValue, len(values))
+	for i, cv := range values {
+		dryRunValues[i] = DryRunValue{
+			DeviceResourceName: cv.DeviceResourceName,
+			ValueType:          cv.Type,
+			Value:              cv.Value,
+		}
+	}
+	return DryRunResponse{
+		BaseResponse: commonDTO.NewBaseResponse(requestId, "", statusCode),
+		Values:       dryRunValues,
+	}
+}
@@ -0,0 +1,250 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+)
+
+// accessLimiter enforces a concurrency limit and a token bucket rate limit on the ProtocolDriver
+// calls of a device or of a group of devices sharing a protocol property.
+type accessLimiter struct {
+	name    string
+	slots   chan struct{}
+	rate    float64
+	burst   float64
+	maxWait time.Duration
+	mutex   sync.Mutex
+	tokens  float64
+	last    time.Time
+	// devices are the names of the devices using the limiter, it is removed once all of them are removed
+	devices map[string]struct{}
+}
+
+var accessLimiters = struct {
+	mutex    sync.Mutex
+	limiters map[string]*accessLimiter
+}{
+	limiters: make(map[string]*accessLimiter),
+}
+
+// acquireDriverAccess waits until the ProtocolDriver may be called for the device according to the
+// configured AccessLimits. The returned function must be called once the driver call has returned.
+func acquireDriverAccess(ctx context.Context, device models.Device, dic *di.Container) (func(), errors.EdgeX) {
+	limiters := deviceAccessLimiters(device, dic)
+	if len(limiters) == 0 {
+		return func() {}, nil
+	}
+
+	acquired := make([]*accessLimiter, 0, len(limiters))
+	release := func() {
+		for _, limiter := range acquired {
+			limiter.release()
+		}
+	}
+	for _, limiter := range limiters {
+		if err := limiter.acquire(ctx, device.Name); err != nil {
+			release()
+			return nil, err
+		}
+		acquired = append(acquired, limiter)
+	}
+	return release, nil
+}
+
+// deviceAccessLimiters returns the limiters applying to the device, sorted by name so that the
+// limiters shared between devices are always acquired in the same order.
+func deviceAccessLimiters(device models.Device, dic *di.Container) []*accessLimiter {
+	limits := container.ConfigurationFrom(dic.Get).Device.AccessLimits
+
+	var limiters []*accessLimiter
+	limit := limits.Default
+	if l, ok := limits.Devices[device.Name]; ok {
+		limit = l
+	}
+	if limiter := accessLimiterFor(fmt.Sprintf("device %s", device.Name), device.Name, limit, dic); limiter != nil {
+		limiters = append(limiters, limiter)
+	}
+
+	for protocol, limit := range limits.Protocols {
+		value, ok := device.Protocols[protocol][limit.Property]
+		if !ok {
+			continue
+		}
+		name := fmt.Sprintf("%s %s %v", protocol, limit.Property, value)
+		if limiter := accessLimiterFor(name, device.Name, limit, dic); limiter != nil {
+			limiters = append(limiters, limiter)
+		}
+	}
+
+	sort.Slice(limiters, func(i, j int) bool {
+		return limiters[i].name < limiters[j].name
+	})
+	return limiters
+}
+
+// accessLimiterFor returns the limiter with the given name used by the device, it is created with the limit
+// on first use. It returns nil if the limit does not restrict anything.
+func accessLimiterFor(name string, deviceName string, limit config.AccessLimitInfo, dic *di.Container) *accessLimiter {
+	if limit.MaxConcurrent <= 0 && limit.Rate <= 0 {
+		return nil
+	}
+
+	accessLimiters.mutex.Lock()
+	defer accessLimiters.mutex.Unlock()
+
+	if limiter, ok := accessLimiters.limiters[name]; ok {
+		limiter.devices[deviceName] = struct{}{}
+		return limiter
+	}
+
+	limiter := &accessLimiter{
+		name:    name,
+		rate:    limit.Rate,
+		burst:   float64(limit.Burst),
+		maxWait: -1,
+		devices: map[string]struct{}{deviceName: {}},
+	}
+	if limit.MaxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, limit.MaxConcurrent)
+	}
+	if limiter.burst < 1 {
+		limiter.burst = 1
+	}
+	limiter.tokens = limiter.burst
+	limiter.last = time.Now()
+	if limit.MaxWait != "" {
+		maxWait, err := time.ParseDuration(limit.MaxWait)
+		if err != nil {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			lc.Warnf("failed to parse AccessLimits MaxWait '%s' of %s, waiting until the command times out: %v", limit.MaxWait, name, err)
+		} else {
+			limiter.maxWait = maxWait
+		}
+	}
+
+	accessLimiters.limiters[name] = limiter
+	return limiter
+}
+
+// removeAccessLimiters removes the limiter of the removed device and the shared limiters which are not
+// used by any other device. The calls in progress still release the removed limiters they acquired.
+func removeAccessLimiters(deviceName string) {
+	accessLimiters.mutex.Lock()
+	defer accessLimiters.mutex.Unlock()
+
+	for name, limiter := range accessLimiters.limiters {
+		delete(limiter.devices, deviceName)
+		if len(limiter.devices) == 0 {
+			delete(accessLimiters.limiters, name)
+		}
+	}
+}
+
+func (l *accessLimiter) acquire(ctx context.Context, deviceName string) errors.EdgeX {
+	if err := l.acquireSlot(ctx, deviceName); err != nil {
+		return err
+	}
+	if err := l.acquireToken(ctx, deviceName); err != nil {
+		l.release()
+		return err
+	}
+	return nil
+}
+
+func (l *accessLimiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+func (l *accessLimiter) acquireSlot(ctx context.Context, deviceName string) errors.EdgeX {
+	if l.slots == nil {
+		return nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if l.maxWait == 0 {
+		return l.rejectedError(deviceName, "concurrency")
+	}
+
+	var timeout <-chan time.Time
+	if l.maxWait > 0 {
+		timer := time.NewTimer(l.maxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return contextError(ctx, deviceName)
+	case <-timeout:
+		return l.rejectedError(deviceName, "concurrency")
+	}
+}
+
+// acquireToken takes a token from the bucket, or reserves the next one and waits for it to be available.
+func (l *accessLimiter) acquireToken(ctx context.Context, deviceName string) errors.EdgeX {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mutex.Unlock()
+		return nil
+	}
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	if l.maxWait >= 0 && wait > l.maxWait {
+		l.mutex.Unlock()
+		return l.rejectedError(deviceName, "rate")
+	}
+	l.tokens--
+	l.mutex.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// hand the reserved token back
+		l.mutex.Lock()
+		l.tokens++
+		l.mutex.Unlock()
+		return contextError(ctx, deviceName)
+	}
+}
+
+func (l *accessLimiter) rejectedError(deviceName string, limit string) errors.EdgeX {
+	errMsg := fmt.Sprintf("ProtocolDriver call for device %s rejected, %s limit of %s exceeded", deviceName, limit, l.name)
+	return errors.NewCommonEdgeX(errors.KindServiceUnavailable, errMsg, nil)
+}
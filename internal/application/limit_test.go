@@ -0,0 +1,211 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces/mocks"
+)
+
+func accessLimitsDic(t *testing.T, limits config.AccessLimitsInfo) *di.Container {
+	dic := mockDic(t, &config.ConfigurationStruct{Device: config.DeviceInfo{AccessLimits: limits}}, &mocks.ProtocolDriver{})
+	t.Cleanup(func() {
+		removeAccessLimiters(testDevice)
+		removeAccessLimiters(otherDevice)
+	})
+	return dic
+}
+
+func TestAcquireDriverAccess_Unlimited(t *testing.T) {
+	dic := accessLimitsDic(t, config.AccessLimitsInfo{})
+	release, err := acquireDriverAccess(context.Background(), testDeviceModel(t, testDevice), dic)
+	require.NoError(t, err)
+	release()
+	assert.Empty(t, deviceAccessLimiters(testDeviceModel(t, testDevice), dic))
+}
+
+func TestAcquireDriverAccess_MaxConcurrent(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxWait  string
+		timeout  time.Duration
+		expected string
+	}{
+		{"rejected at once", "0s", time.Second, "concurrency limit of device test-device exceeded"},
+		{"rejected after MaxWait", "20ms", time.Second, "concurrency limit of device test-device exceeded"},
+		{"waiting until the command times out", "", 20 * time.Millisecond, "timed out"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dic := accessLimitsDic(t, config.AccessLimitsInfo{Default: config.AccessLimitInfo{MaxConcurrent: 1, MaxWait: tt.maxWait}})
+			device := testDeviceModel(t, testDevice)
+
+			release, err := acquireDriverAccess(context.Background(), device, dic)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			_, err = acquireDriverAccess(ctx, device, dic)
+			require.Error(t, err)
+			assert.Equal(t, errors.KindServiceUnavailable, errors.Kind(err))
+			assert.Contains(t, err.Error(), tt.expected)
+
+			// the slot is available again once released
+			release()
+			release, err = acquireDriverAccess(ctx, device, dic)
+			require.NoError(t, err)
+			release()
+		})
+	}
+}
+
+func TestAcquireDriverAccess_MaxConcurrentWaits(t *testing.T) {
+	dic := accessLimitsDic(t, config.AccessLimitsInfo{Default: config.AccessLimitInfo{MaxConcurrent: 1}})
+	device := testDeviceModel(t, testDevice)
+
+	release, err := acquireDriverAccess(context.Background(), device, dic)
+	require.NoError(t, err)
+	time.AfterFunc(30*time.Millisecond, release)
+
+	start := time.Now()
+	second, err := acquireDriverAccess(context.Background(), device, dic)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+	second()
+}
+
+func TestAcquireDriverAccess_Rate(t *testing.T) {
+	dic := accessLimitsDic(t, config.AccessLimitsInfo{Default: config.AccessLimitInfo{Rate: 20, Burst: 2}})
+	device := testDeviceModel(t, testDevice)
+
+	// the burst is available at once, the next call waits for a token
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := acquireDriverAccess(context.Background(), device, dic)
+		require.NoError(t, err)
+		release()
+	}
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 40*time.Millisecond)
+	assert.Less(t, elapsed, time.Second)
+}
+
+func TestAcquireDriverAccess_RateMaxWait(t *testing.T) {
+	dic := accessLimitsDic(t, config.AccessLimitsInfo{Default: config.AccessLimitInfo{Rate: 1, MaxWait: "100ms"}})
+	device := testDeviceModel(t, testDevice)
+
+	release, err := acquireDriverAccess(context.Background(), device, dic)
+	require.NoError(t, err)
+	release()
+
+	// the next token is a second away, which exceeds MaxWait
+	_, err = acquireDriverAccess(context.Background(), device, dic)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindServiceUnavailable, errors.Kind(err))
+	assert.Contains(t, err.Error(), "rate limit of device test-device exceeded")
+}
+
+func TestAcquireDriverAccess_RateCanceled(t *testing.T) {
+	dic := accessLimitsDic(t, config.AccessLimitsInfo{Default: config.AccessLimitInfo{Rate: 10}})
+	device := testDeviceModel(t, testDevice)
+
+	release, err := acquireDriverAccess(context.Background(), device, dic)
+	require.NoError(t, err)
+	release()
+
+	// the token reserved by the canceled call is handed back to the next one
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = acquireDriverAccess(ctx, device, dic)
+	require.Error(t, err)
+
+	start := time.Now()
+	release, err = acquireDriverAccess(context.Background(), device, dic)
+	require.NoError(t, err)
+	release()
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestDeviceAccessLimiters(t *testing.T) {
+	limits := config.AccessLimitsInfo{
+		Default: config.AccessLimitInfo{MaxConcurrent: 2},
+		Devices: map[string]config.AccessLimitInfo{otherDevice: {MaxConcurrent: 1}},
+		Protocols: map[string]config.AccessLimitInfo{
+			testProtocol: {MaxConcurrent: 1, Property: "Address"},
+			"bacnet-ip":  {MaxConcurrent: 1, Property: "Network"},
+			"serial":     {MaxConcurrent: 1, Property: "Port"},
+		},
+	}
+	dic := accessLimitsDic(t, limits)
+	device := testDeviceModel(t, testDevice)
+	device.Protocols = map[string]models.ProtocolProperties{
+		testProtocol: {"Address": testAddress},
+		"serial":     {"Port": "/dev/ttyS0"},
+		"bacnet-ip":  {"Network": "1"},
+	}
+
+	// the limiters are always acquired in the order of their names
+	limiters := deviceAccessLimiters(device, dic)
+	names := make([]string, len(limiters))
+	for i, limiter := range limiters {
+		names[i] = limiter.name
+	}
+	assert.Equal(t, []string{"bacnet-ip Network 1", "device test-device", "modbus-tcp Address 10.0.0.1", "serial Port /dev/ttyS0"}, names)
+	assert.Equal(t, 2, cap(limiters[1].slots))
+
+	// the devices on the same address share the protocol limiter but not the device one
+	otherLimiters := deviceAccessLimiters(testDeviceModel(t, otherDevice), dic)
+	require.Len(t, otherLimiters, 2)
+	assert.Equal(t, "device other-device", otherLimiters[0].name)
+	assert.Equal(t, 1, cap(otherLimiters[0].slots))
+	assert.Same(t, limiters[2], otherLimiters[1])
+
+	release, err := acquireDriverAccess(context.Background(), device, dic)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = acquireDriverAccess(ctx, testDeviceModel(t, otherDevice), dic)
+	require.Error(t, err)
+	release()
+}
+
+func TestRemoveAccessLimiters(t *testing.T) {
+	limits := config.AccessLimitsInfo{
+		Default:   config.AccessLimitInfo{MaxConcurrent: 1},
+		Protocols: map[string]config.AccessLimitInfo{testProtocol: {MaxConcurrent: 1, Property: "Address"}},
+	}
+	dic := accessLimitsDic(t, limits)
+	deviceAccessLimiters(testDeviceModel(t, testDevice), dic)
+	deviceAccessLimiters(testDeviceModel(t, otherDevice), dic)
+
+	limiterNames := func() []string {
+		accessLimiters.mutex.Lock()
+		defer accessLimiters.mutex.Unlock()
+		var names []string
+		for name := range accessLimiters.limiters {
+			names = append(names, name)
+		}
+		return names
+	}
+	assert.ElementsMatch(t, []string{"device test-device", "device other-device", "modbus-tcp Address 10.0.0.1"}, limiterNames())
+
+	// the shared limiter is kept as long as a device uses it
+	removeAccessLimiters(testDevice)
+	assert.ElementsMatch(t, []string{"device other-device", "modbus-tcp Address 10.0.0.1"}, limiterNames())
+	removeAccessLimiters(otherDevice)
+	assert.Empty(t, limiterNames())
+}
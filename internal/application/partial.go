@@ -0,0 +1,167 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/transformer"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// ResourceError is the error of a device resource which failed to be read by a partial read.
+type ResourceError struct {
+	DeviceResourceName string `json:"deviceResourceName"`
+	StatusCode         int    `json:"statusCode"`
+	Message            string `json:"message"`
+}
+
+// PartialEventResponse is the response of a GET command whose Event lacks the readings of the device
+// resources which failed to be read, these being listed in Errors.
+type PartialEventResponse struct {
+	responses.EventResponse `json:",inline"`
+	Errors                  []ResourceError `json:"errors"`
+}
+
+// NewPartialEventResponse creates a PartialEventResponse with the given Event and resource errors.
+func NewPartialEventResponse(requestId string, statusCode int, event dtos.Event, resourceErrors []ResourceError) PartialEventResponse {
+	return PartialEventResponse{
+		EventResponse: responses.NewEventResponse(requestId, "", statusCode, event),
+		Errors:        resourceErrors,
+	}
+}
+
+// Encode encodes the response the same way responses.EventResponse does, i.e. as CBOR if the Event
+// contains a binary reading or if all the Events are configured to be encoded as CBOR, otherwise as JSON.
+func (r *PartialEventResponse) Encode() ([]byte, string, error) {
+	encoding := common.ContentTypeJSON
+	for _, reading := range r.Event.Readings {
+		if reading.ValueType == common.ValueTypeBinary {
+			encoding = common.ContentTypeCBOR
+			break
+		}
+	}
+	if v := os.Getenv(common.EnvEncodeAllEvents); v == common.ValueTrue {
+		encoding = common.ContentTypeCBOR
+	}
+
+	if encoding == common.ContentTypeCBOR {
+		data, err := cbor.Marshal(r)
+		if err != nil {
+			return nil, "", errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode PartialEventResponse to CBOR", err)
+		}
+		return data, encoding, nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, "", errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode PartialEventResponse to JSON", err)
+	}
+	return data, encoding, nil
+}
+
+// ResourceErrorsResponse is the response of a GET command not returning its Event, which lists the device
+// resources which failed to be read by a partial read.
+type ResourceErrorsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Errors                 []ResourceError `json:"errors"`
+}
+
+// NewResourceErrorsResponse creates a ResourceErrorsResponse with the given resource errors.
+func NewResourceErrorsResponse(requestId string, statusCode int, resourceErrors []ResourceError) ResourceErrorsResponse {
+	return ResourceErrorsResponse{
+		BaseResponse: commonDTO.NewBaseResponse(requestId, "", statusCode),
+		Errors:       resourceErrors,
+	}
+}
+
+// partialReadFailures converts the errors of the resources reported by the ProtocolDriver, the errors
+// which are not EdgeX errors being server errors.
+func partialReadFailures(partialErr *sdkModels.PartialReadError) map[string]errors.EdgeX {
+	failures := make(map[string]errors.EdgeX, len(partialErr.Errors))
+	for name, err := range partialErr.Errors {
+		kind := errors.Kind(err)
+		if kind == errors.KindUnknown {
+			kind = errors.KindServerError
+		}
+		failures[name] = errors.NewCommonEdgeX(kind, fmt.Sprintf("failed to read DeviceResource %s", name), err)
+	}
+	return failures
+}
+
+// readResultsToEvent converts the values read from the device to an Event. If the PartialReads setting is
+// enabled, the resources which failed to be read or transformed are returned as ResourceErrors, in the order
+// of the requests, instead of failing the whole read, which only fails if none of the resources was read.
+func readResultsToEvent(results []*sdkModels.CommandValue, failures map[string]errors.EdgeX, reqs []sdkModels.CommandRequest, deviceName string, sourceName string, units string, dic *di.Container) (*dtos.Event, []ResourceError, errors.EdgeX) {
+	deviceInfo := container.ConfigurationFrom(dic.Get).Device
+	if !deviceInfo.PartialReads {
+		event, err := transformer.CommandValuesToEventDTO(results, deviceName, sourceName, deviceInfo.DataTransform, units, dic)
+		return event, nil, err
+	}
+
+	event, transformFailures, err := transformer.CommandValuesToPartialEventDTO(results, deviceName, sourceName, deviceInfo.DataTransform, units, dic)
+	if err != nil {
+		return nil, nil, err
+	}
+	merged := make(map[string]errors.EdgeX, len(failures)+len(transformFailures))
+	for name, err := range failures {
+		merged[name] = err
+	}
+	for name, err := range transformFailures {
+		merged[name] = err
+	}
+	if len(merged) == 0 {
+		return event, nil, nil
+	}
+
+	resourceErrors := sortedResourceErrors(merged, reqs)
+	if event == nil {
+		first := resourceErrors[0]
+		errMsg := fmt.Sprintf("none of the resources of %s could be read", sourceName)
+		return nil, nil, errors.NewCommonEdgeX(errors.Kind(merged[first.DeviceResourceName]), errMsg, merged[first.DeviceResourceName])
+	}
+	return event, resourceErrors, nil
+}
+
+// sortedResourceErrors lists the errors in the order of the requested resources, followed by the errors of
+// the other resources returned by the ProtocolDriver sorted by name.
+func sortedResourceErrors(failures map[string]errors.EdgeX, reqs []sdkModels.CommandRequest) []ResourceError {
+	resourceErrors := make([]ResourceError, 0, len(failures))
+	listed := make(map[string]bool, len(failures))
+	add := func(name string) {
+		if err, ok := failures[name]; ok && !listed[name] {
+			listed[name] = true
+			resourceErrors = append(resourceErrors, ResourceError{DeviceResourceName: name, StatusCode: err.Code(), Message: err.Error()})
+		}
+	}
+	for _, req := range reqs {
+		add(req.DeviceResourceName)
+	}
+
+	others := make([]string, 0, len(failures))
+	for name := range failures {
+		if !listed[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		add(name)
+	}
+	return resourceErrors
+}
@@ -0,0 +1,271 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	gometrics "github.com/rcrowley/go-metrics"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+const (
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMultiplier     = 2
+	driverCallRetriesName      = "DriverCallRetries"
+)
+
+var defaultRetryableErrorKinds = []errors.ErrKind{
+	errors.KindServerError,
+	errors.KindCommunicationError,
+	errors.KindIOError,
+	errors.KindServiceUnavailable,
+}
+
+// retryPolicy is the Retry configuration of a single driver call with the overrides of the device
+// and the device resources applied.
+type retryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	multiplier     float64
+	jitter         float64
+	retryable      map[errors.ErrKind]bool
+}
+
+var driverCallRetries = struct {
+	mutex    sync.Mutex
+	counters map[string]gometrics.Counter
+}{
+	counters: make(map[string]gometrics.Counter),
+}
+
+// withRetry invokes call until it succeeds, returns an error which is not retryable, or the MaxAttempts
+// of the retry policy is reached. The call reports whether the ProtocolDriver has been called, the errors
+// returned before calling the driver are never retried. The writes are only retried if RetryWrites is
+// enabled. The outcome of the last call is returned.
+func withRetry(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, write bool, dic *di.Container, call func() (bool, errors.EdgeX)) (bool, errors.EdgeX) {
+	policy := newRetryPolicy(device, reqs, write, dic)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	backoff := policy.initialBackoff
+	for attempt := 1; ; attempt++ {
+		driverCalled, err := call()
+		if err == nil || !driverCalled || attempt >= policy.maxAttempts || !policy.retryable[driverErrorKind(err)] || ctx.Err() != nil {
+			return driverCalled, err
+		}
+
+		wait := policy.jittered(backoff)
+		lc.Debugf("ProtocolDriver call for device %s failed (attempt %d of %d), retrying in %s: %v", device.Name, attempt, policy.maxAttempts, wait, err)
+		countDriverCallRetry(device.Name, dic)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return true, contextError(ctx, device.Name)
+		case <-timer.C:
+		}
+
+		backoff = policy.nextBackoff(backoff)
+	}
+}
+
+// driverErrorKind returns the kind of the error returned by the driver, which is wrapped as
+// UnexpectedServerError unless the driver returned an EdgeX error of a specific kind.
+func driverErrorKind(err errors.EdgeX) errors.ErrKind {
+	if cause := stdErrors.Unwrap(err); cause != nil {
+		if kind := errors.Kind(cause); kind != errors.KindUnknown {
+			return kind
+		}
+	}
+	return errors.Kind(err)
+}
+
+func newRetryPolicy(device models.Device, reqs []sdkModels.CommandRequest, write bool, dic *di.Container) retryPolicy {
+	retryInfo := container.ConfigurationFrom(dic.Get).Device.Retry
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	if write && !retryInfo.RetryWrites {
+		return retryPolicy{maxAttempts: 1}
+	}
+
+	policy := retryPolicy{
+		maxAttempts:    retryInfo.MaxAttempts,
+		initialBackoff: parseRetryDuration(retryInfo.InitialBackoff, "InitialBackoff", defaultRetryInitialBackoff, dic),
+		maxBackoff:     parseRetryDuration(retryInfo.MaxBackoff, "MaxBackoff", defaultRetryMaxBackoff, dic),
+		multiplier:     retryInfo.Multiplier,
+		jitter:         retryInfo.Jitter,
+		retryable:      make(map[errors.ErrKind]bool),
+	}
+	if policy.multiplier < 1 {
+		policy.multiplier = defaultRetryMultiplier
+	}
+	if policy.jitter < 0 || policy.jitter > 1 {
+		lc.Warnf("Retry Jitter %v is out of range [0, 1], no jitter applied", policy.jitter)
+		policy.jitter = 0
+	}
+	if len(retryInfo.RetryableErrorKinds) == 0 {
+		for _, kind := range defaultRetryableErrorKinds {
+			policy.retryable[kind] = true
+		}
+	}
+	for _, kind := range retryInfo.RetryableErrorKinds {
+		policy.retryable[errors.ErrKind(kind)] = true
+	}
+
+	// the device overrides are taken from its protocol properties, from the first protocol in name order
+	// defining them if several do
+	owner := fmt.Sprintf("device %s", device.Name)
+	protocols := make([]string, 0, len(device.Protocols))
+	for protocol := range device.Protocols {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+	var maxAttemptsFound, backoffFound bool
+	for _, protocol := range protocols {
+		properties := device.Protocols[protocol]
+		if maxAttempts, ok := maxAttemptsOverride(properties, owner, lc); ok && !maxAttemptsFound {
+			policy.maxAttempts = maxAttempts
+			maxAttemptsFound = true
+		}
+		if backoff, ok := backoffOverride(properties, owner, lc); ok && !backoffFound {
+			policy.initialBackoff = backoff
+			backoffFound = true
+		}
+	}
+
+	// the device resource overrides take precedence, the lowest MaxAttempts wins when several resources
+	// are read or written together as not all of them may be safe to retry
+	resourceMaxAttempts := -1
+	for _, req := range reqs {
+		owner = fmt.Sprintf("device resource %s", req.DeviceResourceName)
+		if maxAttempts, ok := maxAttemptsOverride(req.Attributes, owner, lc); ok {
+			if resourceMaxAttempts < 0 || maxAttempts < resourceMaxAttempts {
+				resourceMaxAttempts = maxAttempts
+			}
+		}
+		if backoff, ok := backoffOverride(req.Attributes, owner, lc); ok {
+			policy.initialBackoff = backoff
+		}
+	}
+	if resourceMaxAttempts >= 0 {
+		policy.maxAttempts = resourceMaxAttempts
+	}
+	return policy
+}
+
+func maxAttemptsOverride(properties map[string]any, owner string, lc logger.LoggingClient) (int, bool) {
+	value, ok := properties[sdkCommon.RetryMaxAttempts]
+	if !ok {
+		return 0, false
+	}
+	maxAttempts, err := strconv.Atoi(fmt.Sprint(value))
+	if err != nil {
+		lc.Warnf("failed to parse %s '%v' of %s: %v", sdkCommon.RetryMaxAttempts, value, owner, err)
+		return 0, false
+	}
+	return maxAttempts, true
+}
+
+func backoffOverride(properties map[string]any, owner string, lc logger.LoggingClient) (time.Duration, bool) {
+	value, ok := properties[sdkCommon.RetryBackoff]
+	if !ok {
+		return 0, false
+	}
+	backoff, err := time.ParseDuration(fmt.Sprint(value))
+	if err != nil {
+		lc.Warnf("failed to parse %s '%v' of %s: %v", sdkCommon.RetryBackoff, value, owner, err)
+		return 0, false
+	}
+	return backoff, true
+}
+
+// nextBackoff grows the backoff by the Multiplier, up to MaxBackoff.
+func (p *retryPolicy) nextBackoff(backoff time.Duration) time.Duration {
+	backoff = time.Duration(float64(backoff) * p.multiplier)
+	if backoff > p.maxBackoff {
+		return p.maxBackoff
+	}
+	return backoff
+}
+
+// jittered randomizes the Jitter fraction of the backoff around its value.
+func (p *retryPolicy) jittered(backoff time.Duration) time.Duration {
+	if p.jitter == 0 {
+		return backoff
+	}
+	return time.Duration(float64(backoff) * (1 + p.jitter*(2*rand.Float64()-1))) // nolint: gosec
+}
+
+func parseRetryDuration(value string, name string, defaultValue time.Duration, dic *di.Container) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Warnf("failed to parse Retry %s '%s', using default %s: %v", name, value, defaultValue, err)
+		return defaultValue
+	}
+	return duration
+}
+
+// countDriverCallRetry increments the retry counter of the device, which is registered as
+// DriverCallRetries-<device name> and reported under the DriverCallRetries metric name.
+func countDriverCallRetry(deviceName string, dic *di.Container) {
+	driverCallRetries.mutex.Lock()
+	defer driverCallRetries.mutex.Unlock()
+
+	counter, ok := driverCallRetries.counters[deviceName]
+	if !ok {
+		counter = gometrics.NewCounter()
+		driverCallRetries.counters[deviceName] = counter
+
+		metricsManager := bootstrapContainer.MetricsManagerFrom(dic.Get)
+		if metricsManager != nil {
+			name := fmt.Sprintf("%s-%s", driverCallRetriesName, deviceName)
+			err := metricsManager.Register(name, counter, map[string]string{"device": deviceName})
+			if err != nil {
+				lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+				lc.Errorf("unable to register %s metric. Metric will not be reported: %v", name, err)
+			}
+		}
+	}
+	counter.Inc(1)
+}
+
+// unregisterDriverCallRetries removes the retry counter of a removed device.
+func unregisterDriverCallRetries(deviceName string, dic *di.Container) {
+	driverCallRetries.mutex.Lock()
+	defer driverCallRetries.mutex.Unlock()
+
+	if _, ok := driverCallRetries.counters[deviceName]; !ok {
+		return
+	}
+	delete(driverCallRetries.counters, deviceName)
+	metricsManager := bootstrapContainer.MetricsManagerFrom(dic.Get)
+	if metricsManager != nil {
+		metricsManager.Unregister(fmt.Sprintf("%s-%s", driverCallRetriesName, deviceName))
+	}
+}
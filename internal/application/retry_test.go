@@ -0,0 +1,226 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+func retryDic(t *testing.T, retry config.RetryInfo) *di.Container {
+	dic := mockDic(t, &config.ConfigurationStruct{Device: config.DeviceInfo{Retry: retry}}, &mocks.ProtocolDriver{})
+	t.Cleanup(func() {
+		unregisterDriverCallRetries(testDevice, dic)
+	})
+	return dic
+}
+
+func TestRetryPolicy_NextBackoff(t *testing.T) {
+	dic := retryDic(t, config.RetryInfo{InitialBackoff: "100ms", MaxBackoff: "1s", Multiplier: 3})
+	policy := newRetryPolicy(testDeviceModel(t, testDevice), nil, false, dic)
+
+	backoff := policy.initialBackoff
+	var backoffs []time.Duration
+	for i := 0; i < 4; i++ {
+		backoffs = append(backoffs, backoff)
+		backoff = policy.nextBackoff(backoff)
+	}
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second}, backoffs)
+}
+
+func TestRetryPolicy_Defaults(t *testing.T) {
+	dic := retryDic(t, config.RetryInfo{MaxAttempts: 3, Multiplier: 0.5, Jitter: 2})
+	policy := newRetryPolicy(testDeviceModel(t, testDevice), nil, false, dic)
+
+	assert.Equal(t, defaultRetryInitialBackoff, policy.initialBackoff)
+	assert.Equal(t, defaultRetryMaxBackoff, policy.maxBackoff)
+	assert.Equal(t, float64(defaultRetryMultiplier), policy.multiplier)
+	assert.Zero(t, policy.jitter)
+	for _, kind := range defaultRetryableErrorKinds {
+		assert.True(t, policy.retryable[kind])
+	}
+	assert.False(t, policy.retryable[errors.KindContractInvalid])
+}
+
+func TestRetryPolicy_Jittered(t *testing.T) {
+	policy := retryPolicy{jitter: 0.2}
+	backoff := 100 * time.Millisecond
+	for i := 0; i < 1000; i++ {
+		wait := policy.jittered(backoff)
+		assert.GreaterOrEqual(t, wait, 80*time.Millisecond)
+		assert.LessOrEqual(t, wait, 120*time.Millisecond)
+	}
+	policy.jitter = 0
+	assert.Equal(t, backoff, policy.jittered(backoff))
+}
+
+func TestRetryPolicy_Overrides(t *testing.T) {
+	tests := []struct {
+		name                string
+		protocols           map[string]models.ProtocolProperties
+		attributes          []map[string]any
+		expectedMaxAttempts int
+		expectedBackoff     time.Duration
+	}{
+		{"configuration", nil, nil, 2, 10 * time.Millisecond},
+		{"device", map[string]models.ProtocolProperties{testProtocol: {sdkCommon.RetryMaxAttempts: "4", sdkCommon.RetryBackoff: "1s"}}, nil, 4, time.Second},
+		{"invalid device", map[string]models.ProtocolProperties{testProtocol: {sdkCommon.RetryMaxAttempts: "many", sdkCommon.RetryBackoff: "soon"}}, nil, 2, 10 * time.Millisecond},
+		{"first protocol in name order",
+			map[string]models.ProtocolProperties{"b-protocol": {sdkCommon.RetryMaxAttempts: "3", sdkCommon.RetryBackoff: "3s"}, "a-protocol": {sdkCommon.RetryMaxAttempts: "4"}},
+			nil, 4, 3 * time.Second},
+		{"device resource over device",
+			map[string]models.ProtocolProperties{testProtocol: {sdkCommon.RetryMaxAttempts: "4", sdkCommon.RetryBackoff: "1s"}},
+			[]map[string]any{{sdkCommon.RetryMaxAttempts: 5, sdkCommon.RetryBackoff: "2s"}},
+			5, 2 * time.Second},
+		{"lowest device resource MaxAttempts",
+			map[string]models.ProtocolProperties{testProtocol: {sdkCommon.RetryMaxAttempts: "4"}},
+			[]map[string]any{{sdkCommon.RetryMaxAttempts: 5}, {sdkCommon.RetryMaxAttempts: 1}, {}},
+			1, 10 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dic := retryDic(t, config.RetryInfo{MaxAttempts: 2, InitialBackoff: "10ms"})
+			device := testDeviceModel(t, testDevice)
+			device.Protocols = tt.protocols
+			reqs := make([]sdkModels.CommandRequest, len(tt.attributes))
+			for i, attributes := range tt.attributes {
+				reqs[i] = sdkModels.CommandRequest{DeviceResourceName: resource1, Attributes: attributes}
+			}
+
+			policy := newRetryPolicy(device, reqs, false, dic)
+			assert.Equal(t, tt.expectedMaxAttempts, policy.maxAttempts)
+			assert.Equal(t, tt.expectedBackoff, policy.initialBackoff)
+		})
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	driverErr := errors.NewCommonEdgeX(errors.KindServerError, "", stdErrors.New("no response"))
+	tests := []struct {
+		name             string
+		retry            config.RetryInfo
+		write            bool
+		err              errors.EdgeX
+		driverCalled     bool
+		expectedAttempts int
+	}{
+		{"retried up to MaxAttempts", config.RetryInfo{MaxAttempts: 3}, false, driverErr, true, 3},
+		{"retries disabled", config.RetryInfo{MaxAttempts: 1}, false, driverErr, true, 1},
+		{"driver not called", config.RetryInfo{MaxAttempts: 3}, false, driverErr, false, 1},
+		{"error kind of the driver retryable",
+			config.RetryInfo{MaxAttempts: 3, RetryableErrorKinds: []string{string(errors.KindCommunicationError)}},
+			false, errors.NewCommonEdgeX(errors.KindServerError, "", errors.NewCommonEdgeX(errors.KindCommunicationError, "timeout", nil)), true, 3},
+		{"error kind not retryable",
+			config.RetryInfo{MaxAttempts: 3, RetryableErrorKinds: []string{string(errors.KindCommunicationError)}},
+			false, driverErr, true, 1},
+		{"write not retried by default", config.RetryInfo{MaxAttempts: 3}, true, driverErr, true, 1},
+		{"write retried with RetryWrites", config.RetryInfo{MaxAttempts: 3, RetryWrites: true}, true, driverErr, true, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.retry.InitialBackoff = "1ms"
+			dic := retryDic(t, tt.retry)
+
+			attempts := 0
+			driverCalled, err := withRetry(context.Background(), testDeviceModel(t, testDevice), nil, tt.write, dic, func() (bool, errors.EdgeX) {
+				attempts++
+				return tt.driverCalled, tt.err
+			})
+			require.Error(t, err)
+			assert.Equal(t, tt.driverCalled, driverCalled)
+			assert.Equal(t, tt.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestWithRetry_Succeeds(t *testing.T) {
+	dic := retryDic(t, config.RetryInfo{MaxAttempts: 5, InitialBackoff: "1ms"})
+
+	attempts := 0
+	_, err := withRetry(context.Background(), testDeviceModel(t, testDevice), nil, false, dic, func() (bool, errors.EdgeX) {
+		attempts++
+		if attempts < 3 {
+			return true, errors.NewCommonEdgeX(errors.KindServerError, "failed", nil)
+		}
+		return true, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithRetry_Canceled(t *testing.T) {
+	dic := retryDic(t, config.RetryInfo{MaxAttempts: 5, InitialBackoff: "1s"})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	_, err := withRetry(ctx, testDeviceModel(t, testDevice), nil, false, dic, func() (bool, errors.EdgeX) {
+		attempts++
+		return true, errors.NewCommonEdgeX(errors.KindServerError, "failed", nil)
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.KindServiceUnavailable, errors.Kind(err))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithRetry_RetryCounter(t *testing.T) {
+	dic := retryDic(t, config.RetryInfo{MaxAttempts: 3, InitialBackoff: "1ms"})
+	metricsManager := &bootstrapMocks.MetricsManager{}
+	metricsManager.On("Register", "DriverCallRetries-test-device", mock.Anything, map[string]string{"device": testDevice}).Return(nil).Once()
+	metricsManager.On("Unregister", "DriverCallRetries-test-device").Return().Once()
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.MetricsManagerInterfaceName: func(get di.Get) any {
+			return metricsManager
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		_, _ = withRetry(context.Background(), testDeviceModel(t, testDevice), nil, false, dic, func() (bool, errors.EdgeX) {
+			return true, errors.NewCommonEdgeX(errors.KindServerError, "failed", nil)
+		})
+	}
+
+	// the counter is registered once and counts every retry, not the first attempts
+	metricsManager.AssertNumberOfCalls(t, "Register", 1)
+	counter := metricsManager.Calls[0].Arguments.Get(1).(gometrics.Counter)
+	assert.Equal(t, int64(4), counter.Count())
+
+	unregisterDriverCallRetries(testDevice, dic)
+	metricsManager.AssertCalled(t, "Unregister", "DriverCallRetries-test-device")
+}
+
+func TestHandleWriteCommands_NotRetried(t *testing.T) {
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleWriteCommands", testDevice, mock.Anything, mock.Anything, mock.Anything).Return(stdErrors.New("no response"))
+	dic := mockDic(t, &config.ConfigurationStruct{
+		Device: config.DeviceInfo{Retry: config.RetryInfo{MaxAttempts: 3, InitialBackoff: "1ms"}},
+	}, driver)
+	t.Cleanup(func() {
+		unregisterDriverCallRetries(testDevice, dic)
+	})
+
+	err := handleWriteCommands(context.Background(), testDeviceModel(t, testDevice), commandRequests(resource1), []*sdkModels.CommandValue{stringValue(t, resource1, "on")}, dic)
+	require.Error(t, err)
+	driver.AssertNumberOfCalls(t, "HandleWriteCommands", 1)
+}
@@ -0,0 +1,93 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// snapshotWrite reads the current values of the resources about to be written by a transactional write, in
+// the order of the requests, so that they can be written back by rollbackWrite. No snapshot is taken if the
+// write is not transactional. The write-only resources cannot be read and therefore cannot be written
+// transactionally.
+func snapshotWrite(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, transactional bool, dic *di.Container) ([]*sdkModels.CommandValue, errors.EdgeX) {
+	if !transactional {
+		return nil, nil
+	}
+	for _, req := range reqs {
+		dr, ok := cache.Profiles().DeviceResource(device.ProfileName, req.DeviceResourceName)
+		if ok && dr.Properties.ReadWrite == common.ReadWrite_W {
+			errMsg := fmt.Sprintf("DeviceResource %s is marked as write-only and cannot be written transactionally", dr.Name)
+			return nil, errors.NewCommonEdgeX(errors.KindNotAllowed, errMsg, nil)
+		}
+	}
+
+	results, failures, err := handleReadCommands(ctx, device, reqs, 0, dic)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	values := make(map[string]*sdkModels.CommandValue, len(results))
+	for _, cv := range results {
+		if cv != nil {
+			values[cv.DeviceResourceName] = cv
+		}
+	}
+
+	snapshot := make([]*sdkModels.CommandValue, len(reqs))
+	for i, req := range reqs {
+		if failure, ok := failures[req.DeviceResourceName]; ok {
+			return nil, failure
+		}
+		cv, ok := values[req.DeviceResourceName]
+		if !ok {
+			errMsg := fmt.Sprintf("no value of DeviceResource %s was read", req.DeviceResourceName)
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+		}
+		snapshot[i] = cv
+	}
+	return snapshot, nil
+}
+
+// rollbackWrite writes the snapshot taken by snapshotWrite back to the device after the transactional write
+// failed with cause, and returns cause extended with the resources which were rolled back, or failed to be.
+// The rollback is bounded by its own CommandTimeout as the command one may already be exhausted.
+func rollbackWrite(device models.Device, commandName string, reqs []sdkModels.CommandRequest, snapshot []*sdkModels.CommandValue, cause errors.EdgeX, dic *di.Container) errors.EdgeX {
+	if snapshot == nil {
+		return cause
+	}
+
+	ctx, cancel := commandContext(context.Background(), device.Name, commandName, dic)
+	defer cancel()
+
+	names := make([]string, len(reqs))
+	for i, req := range reqs {
+		names[i] = req.DeviceResourceName
+	}
+	resources := strings.Join(names, ", ")
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	if err := handleWriteCommands(ctx, device, reqs, snapshot, dic); err != nil {
+		lc.Errorf("Failed to roll back DeviceResource(s) %s of device %s: %v", resources, device.Name, err)
+		errMsg := fmt.Sprintf("failed to roll back DeviceResource(s) %s for %s: %v", resources, device.Name, err)
+		return errors.NewCommonEdgeX(errors.Kind(cause), errMsg, cause)
+	}
+
+	lc.Infof("Rolled back DeviceResource(s) %s of device %s: %v", resources, device.Name, cause)
+	errMsg := fmt.Sprintf("rolled back DeviceResource(s) %s for %s", resources, device.Name)
+	return errors.NewCommonEdgeX(errors.Kind(cause), errMsg, cause)
+}
@@ -0,0 +1,118 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/transformer"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+const (
+	defaultWriteVerificationTimeout  = time.Second
+	defaultWriteVerificationInterval = 100 * time.Millisecond
+)
+
+// verifyWrite reads back the written resources whose ds-verify attribute is true, or all the written resources
+// if verify is set, until the values read match the written ones or the WriteVerification Timeout elapses. The
+// write-only resources cannot be read back and are not verified.
+func verifyWrite(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue, verify bool, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	var readReqs []sdkModels.CommandRequest
+	var written []*sdkModels.CommandValue
+	var drs []models.DeviceResource
+	for i, req := range reqs {
+		dr, ok := cache.Profiles().DeviceResource(device.ProfileName, req.DeviceResourceName)
+		if !ok || (!verify && fmt.Sprint(dr.Attributes[sdkCommon.Verify]) != common.ValueTrue) {
+			continue
+		}
+		if dr.Properties.ReadWrite == common.ReadWrite_W {
+			lc.Debugf("DeviceResource %s is marked as write-only, skipping the verification of its write", dr.Name)
+			continue
+		}
+		readReqs = append(readReqs, req)
+		written = append(written, params[i])
+		drs = append(drs, dr)
+	}
+	if len(readReqs) == 0 {
+		return nil
+	}
+
+	verification := container.ConfigurationFrom(dic.Get).Device.WriteVerification
+	timeout := parseWriteVerificationDuration(verification.Timeout, "Timeout", defaultWriteVerificationTimeout, dic)
+	interval := parseWriteVerificationDuration(verification.Interval, "Interval", defaultWriteVerificationInterval, dic)
+	verifyCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		err := compareReadBack(verifyCtx, device, readReqs, written, drs, dic)
+		if err == nil {
+			lc.Debugf("Verified the write of %d resource(s) of device %s after %d read(s)", len(readReqs), device.Name, attempt)
+			return nil
+		}
+		lc.Debugf("Write verification of device %s failed (read %d): %v", device.Name, attempt, err)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-verifyCtx.Done():
+			timer.Stop()
+			errMsg := fmt.Sprintf("failed to verify the write of device %s within %s", device.Name, timeout)
+			return errors.NewCommonEdgeX(errors.Kind(err), errMsg, err)
+		case <-timer.C:
+		}
+	}
+}
+
+// compareReadBack reads the written resources once and compares their values with the written ones.
+func compareReadBack(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, written []*sdkModels.CommandValue, drs []models.DeviceResource, dic *di.Container) errors.EdgeX {
+	results, failures, err := handleReadCommands(ctx, device, reqs, 0, dic)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), "failed to read back the written resources", err)
+	}
+
+	values := make(map[string]*sdkModels.CommandValue, len(results))
+	for _, cv := range results {
+		if cv != nil {
+			values[cv.DeviceResourceName] = cv
+		}
+	}
+	for i, dr := range drs {
+		if failure, ok := failures[dr.Name]; ok {
+			return failure
+		}
+		if err := transformer.VerifyWrittenValue(written[i], values[dr.Name], dr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseWriteVerificationDuration(value string, name string, defaultValue time.Duration, dic *di.Container) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Warnf("failed to parse WriteVerification %s '%s', using default %s: %v", name, value, defaultValue, err)
+		return defaultValue
+	}
+	return duration
+}
@@ -0,0 +1,216 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+const (
+	optionAggregate       = "aggregate"
+	optionAggregateWindow = "aggregateWindow"
+
+	aggregateMin    = "min"
+	aggregateMax    = "max"
+	aggregateMean   = "mean"
+	aggregateLast   = "last"
+	aggregateCount  = "count"
+	aggregateStddev = "stddev"
+
+	// aggregationTag and aggregationWindowTag are the tags of the aggregated readings holding the
+	// aggregate function and the window the readings are aggregated over
+	aggregationTag       = "aggregation"
+	aggregationWindowTag = "aggregationWindow"
+)
+
+// aggregation accumulates the numeric readings sampled by an AutoEvent, and computes the aggregate
+// functions of each device resource over a window.
+type aggregation struct {
+	functions []string
+	window    time.Duration
+	schedule  schedule
+	mutex     sync.Mutex
+	resources []string
+	stats     map[string]*resourceStats
+}
+
+// resourceStats holds the statistics of the samples of a device resource, the mean and variance are
+// computed with Welford's algorithm.
+type resourceStats struct {
+	valueType string
+	units     string
+	count     int64
+	min       float64
+	minValue  string
+	max       float64
+	maxValue  string
+	last      string
+	mean      float64
+	m2        float64
+}
+
+func newAggregation(functions []string, window time.Duration, aligned bool, location *time.Location) (*aggregation, error) {
+	for _, f := range functions {
+		switch f {
+		case aggregateMin, aggregateMax, aggregateMean, aggregateLast, aggregateCount, aggregateStddev:
+		default:
+			return nil, fmt.Errorf("unsupported aggregate function '%s'", f)
+		}
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("%s must be positive", optionAggregateWindow)
+	}
+
+	return &aggregation{
+		functions: functions,
+		window:    window,
+		schedule:  &intervalSchedule{interval: window, aligned: aligned, location: location},
+		stats:     make(map[string]*resourceStats),
+	}, nil
+}
+
+// add accumulates the numeric readings, the other readings cannot be aggregated and are ignored.
+func (a *aggregation) add(readings []dtos.BaseReading) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for _, reading := range readings {
+		// the bad values are not aggregated
+		if !isNumeric(reading.ValueType) || reading.Tags[sdkCommon.QualityTag] == models.QualityBad {
+			continue
+		}
+		value, err := strconv.ParseFloat(reading.Value, 64)
+		if err != nil {
+			continue
+		}
+
+		s, ok := a.stats[reading.ResourceName]
+		if !ok {
+			s = &resourceStats{valueType: reading.ValueType, min: value, minValue: reading.Value, max: value, maxValue: reading.Value}
+			a.stats[reading.ResourceName] = s
+			a.resources = append(a.resources, reading.ResourceName)
+		}
+		s.count++
+		if value < s.min {
+			s.min, s.minValue = value, reading.Value
+		}
+		if value > s.max {
+			s.max, s.maxValue = value, reading.Value
+		}
+		s.last = reading.Value
+		s.units = reading.Units
+		delta := value - s.mean
+		s.mean += delta / float64(s.count)
+		s.m2 += delta * (value - s.mean)
+	}
+}
+
+// flush returns the CommandValues of the aggregate functions of every device resource sampled in the
+// window, with the given origin, and starts a new window. The CommandValues, the aggregate functions
+// they hold and the units they are in are in the same order. The counts have no units, the other values
+// are in the units of the last sample of their device resource.
+func (a *aggregation) flush(origin int64) ([]*models.CommandValue, []string, []string, error) {
+	a.mutex.Lock()
+	resources, stats := a.resources, a.stats
+	a.resources, a.stats = nil, make(map[string]*resourceStats)
+	a.mutex.Unlock()
+
+	var cvs []*models.CommandValue
+	var functions []string
+	var units []string
+	for _, resource := range resources {
+		s := stats[resource]
+		for _, f := range a.functions {
+			var cv *models.CommandValue
+			var err error
+			switch f {
+			case aggregateMin:
+				cv, err = numericCommandValue(resource, s.valueType, s.minValue)
+			case aggregateMax:
+				cv, err = numericCommandValue(resource, s.valueType, s.maxValue)
+			case aggregateLast:
+				cv, err = numericCommandValue(resource, s.valueType, s.last)
+			case aggregateMean:
+				cv, err = models.NewCommandValue(resource, common.ValueTypeFloat64, s.mean)
+			case aggregateStddev:
+				cv, err = models.NewCommandValue(resource, common.ValueTypeFloat64, math.Sqrt(s.m2/float64(s.count)))
+			case aggregateCount:
+				cv, err = models.NewCommandValue(resource, common.ValueTypeInt64, s.count)
+			}
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to aggregate %s of device resource %s: %w", f, resource, err)
+			}
+			cv.Origin = origin
+			cvs = append(cvs, cv)
+			functions = append(functions, f)
+			if f == aggregateCount {
+				units = append(units, "")
+			} else {
+				units = append(units, s.units)
+			}
+		}
+	}
+	return cvs, functions, units, nil
+}
+
+// numericCommandValue creates a CommandValue of the numeric value type from the reading value.
+func numericCommandValue(resource string, valueType string, value string) (*models.CommandValue, error) {
+	var v any
+	var err error
+	switch valueType {
+	case common.ValueTypeUint8:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 8)
+		v = uint8(n)
+	case common.ValueTypeUint16:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 16)
+		v = uint16(n)
+	case common.ValueTypeUint32:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 32)
+		v = uint32(n)
+	case common.ValueTypeUint64:
+		v, err = strconv.ParseUint(value, 10, 64)
+	case common.ValueTypeInt8:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 8)
+		v = int8(n)
+	case common.ValueTypeInt16:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 16)
+		v = int16(n)
+	case common.ValueTypeInt32:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 32)
+		v = int32(n)
+	case common.ValueTypeInt64:
+		v, err = strconv.ParseInt(value, 10, 64)
+	case common.ValueTypeFloat32:
+		var f float64
+		f, err = strconv.ParseFloat(value, 32)
+		v = float32(f)
+	case common.ValueTypeFloat64:
+		v, err = strconv.ParseFloat(value, 64)
+	default:
+		return nil, fmt.Errorf("value type %s is not numeric", valueType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return models.NewCommandValue(resource, valueType, v)
+}
@@ -0,0 +1,166 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+)
+
+func TestAggregation_Flush(t *testing.T) {
+	a, err := newAggregation([]string{"min", "max", "mean", "last", "count", "stddev"}, time.Minute, false, time.UTC)
+	require.NoError(t, err)
+
+	for _, value := range []string{"2", "4", "4", "4", "5", "5", "7", "9"} {
+		readings := []dtos.BaseReading{{ResourceName: "r1"}, {ResourceName: "s1"}}
+		readings[0].ValueType = common.ValueTypeInt16
+		readings[0].Value = value
+		readings[1].ValueType = common.ValueTypeString
+		readings[1].Value = "not aggregated"
+		a.add(readings)
+	}
+
+	cvs, functions, _, err := a.flush(123)
+	require.NoError(t, err)
+	require.Len(t, cvs, 6)
+	assert.Equal(t, []string{"min", "max", "mean", "last", "count", "stddev"}, functions)
+	expected := []any{int16(2), int16(9), float64(5), int16(9), int64(8), float64(2)}
+	for i, cv := range cvs {
+		assert.Equal(t, "r1", cv.DeviceResourceName)
+		assert.Equal(t, expected[i], cv.Value, "wrong %s value", functions[i])
+		assert.Equal(t, int64(123), cv.Origin)
+	}
+
+	// a new window is started
+	cvs, _, _, err = a.flush(456)
+	require.NoError(t, err)
+	assert.Empty(t, cvs)
+}
+
+func TestNewExecutor_Aggregation(t *testing.T) {
+	autoEvent := models.AutoEvent{SourceName: "sourceName", Interval: "1s"}
+	e, err := NewExecutor("device-test", autoEvent, map[string]any{"aggregate": "min, max", "aggregateWindow": "15m", "align": true})
+	require.NoError(t, err)
+	require.NotNil(t, e.aggregation)
+	assert.Equal(t, []string{"min", "max"}, e.aggregation.functions)
+	assert.Equal(t, 15*time.Minute, e.aggregation.window)
+
+	invalidOptions := []map[string]any{
+		{"aggregate": "min"},
+		{"aggregate": "median", "aggregateWindow": "15m"},
+		{"aggregate": "min", "aggregateWindow": "0s"},
+	}
+	for _, options := range invalidOptions {
+		_, err = NewExecutor("device-test", autoEvent, options)
+		assert.Error(t, err, "options %v must be invalid", options)
+	}
+}
+
+func TestAggregation_BadQuality(t *testing.T) {
+	a, err := newAggregation([]string{"min", "max"}, time.Minute, false, time.UTC)
+	require.NoError(t, err)
+
+	for _, value := range []string{"2", "1000", "4"} {
+		reading := dtos.BaseReading{ResourceName: "r1"}
+		reading.ValueType = common.ValueTypeInt16
+		reading.Value = value
+		if value == "1000" {
+			reading.Tags = map[string]any{"ds-quality": "Bad"}
+		}
+		a.add([]dtos.BaseReading{reading})
+	}
+
+	cvs, _, _, err := a.flush(123)
+	require.NoError(t, err)
+	require.Len(t, cvs, 2)
+	assert.Equal(t, int16(2), cvs[0].Value)
+	assert.Equal(t, int16(4), cvs[1].Value, "the bad value must not be aggregated")
+}
+
+func TestExecutor_AggregatedEvent(t *testing.T) {
+	// the aggregated values neither pass the Assertion nor match the mappings of the resource
+	profile := dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile-test"},
+		DeviceResources: []dtos.DeviceResource{
+			{Name: "r1", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt16, ReadWrite: common.ReadWrite_R, Assertion: "1", Units: "degC"}},
+		},
+		DeviceCommands: []dtos.DeviceCommand{
+			{Name: "source-1", ReadWrite: common.ReadWrite_R, ResourceOperations: []dtos.ResourceOperation{
+				{DeviceResource: "r1", Mappings: map[string]string{"2": "two"}},
+			}},
+		},
+	}
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DevicesByServiceName", context.Background(), "service-test", 0, -1).Return(responses.MultiDevicesResponse{
+		Devices: []dtos.Device{{Name: "device-1", AdminState: models.Unlocked, OperatingState: models.Up, ServiceName: "service-test", ProfileName: "profile-test"}},
+	}, nil)
+	dpcMock := &clientMocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", context.Background(), "profile-test").Return(responses.DeviceProfileResponse{Profile: profile}, nil)
+	pwcMock := &clientMocks.ProvisionWatcherClient{}
+	pwcMock.On("ProvisionWatchersByServiceName", context.Background(), "service-test", 0, -1).Return(responses.MultiProvisionWatchersResponse{}, nil)
+	dic := testDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{}
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
+		bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) interface{} {
+			return pwcMock
+		},
+	})
+	require.NoError(t, cache.InitCache("service-test", dic))
+
+	e, err := NewExecutor("device-1", models.AutoEvent{SourceName: "source-1", Interval: "1s"}, map[string]any{"aggregate": "min, max, count", "aggregateWindow": "1m"})
+	require.NoError(t, err)
+	// the samples are read in the units requested by ds-units
+	for _, value := range []string{"2", "5"} {
+		reading := dtos.BaseReading{ResourceName: "r1", Units: "degF"}
+		reading.ValueType = common.ValueTypeInt16
+		reading.Value = value
+		e.aggregation.add([]dtos.BaseReading{reading})
+	}
+
+	evt, aggregateErr := e.aggregatedEvent(time.Now(), dic)
+	require.NoError(t, aggregateErr)
+	require.NotNil(t, evt)
+	require.Len(t, evt.Readings, 3)
+	assert.Equal(t, "2", evt.Readings[0].Value)
+	assert.Equal(t, "min", evt.Readings[0].Tags[aggregationTag])
+	assert.Equal(t, "degF", evt.Readings[0].Units)
+	assert.Equal(t, "5", evt.Readings[1].Value)
+	assert.Equal(t, "max", evt.Readings[1].Tags[aggregationTag])
+	assert.Equal(t, "degF", evt.Readings[1].Units)
+	assert.Equal(t, "2", evt.Readings[2].Value)
+	assert.Equal(t, "count", evt.Readings[2].Tags[aggregationTag])
+	assert.Empty(t, evt.Readings[2].Units)
+	dcMock.AssertNotCalled(t, "Update")
+
+	// nothing is aggregated in the new window
+	evt, aggregateErr = e.aggregatedEvent(time.Now(), dic)
+	require.NoError(t, aggregateErr)
+	assert.Nil(t, evt)
+}
@@ -0,0 +1,136 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+)
+
+const (
+	optionDeadband        = "deadband"
+	optionDeadbandPercent = "deadbandPercent"
+	optionHeartbeat       = "heartbeat"
+)
+
+// changeFilter decides whether a reading of an OnChange AutoEvent differs enough from the last published
+// reading to be published. A numeric reading has changed only if the difference exceeds every configured
+// deadband, and all the readings are published once the heartbeat has elapsed since the last publication.
+type changeFilter struct {
+	deadband        *float64
+	deadbandPercent *float64
+	heartbeat       time.Duration
+}
+
+// parseChangeFilter parses the filter from the options with the given names.
+func parseChangeFilter(options map[string]any, deadbandName string, percentName string, heartbeatName string) (changeFilter, error) {
+	var f changeFilter
+	var err error
+	if f.deadband, err = parseDeadband(options, deadbandName); err != nil {
+		return f, err
+	}
+	if f.deadbandPercent, err = parseDeadband(options, percentName); err != nil {
+		return f, err
+	}
+	if value, ok := options[heartbeatName]; ok {
+		f.heartbeat, err = time.ParseDuration(fmt.Sprint(value))
+		if err != nil || f.heartbeat < 0 {
+			return f, fmt.Errorf("invalid %s '%v'", heartbeatName, value)
+		}
+	}
+	return f, nil
+}
+
+func parseDeadband(options map[string]any, name string) (*float64, error) {
+	value, ok := options[name]
+	if !ok {
+		return nil, nil
+	}
+	deadband, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+	if err != nil || deadband < 0 {
+		return nil, fmt.Errorf("invalid %s '%v'", name, value)
+	}
+	return &deadband, nil
+}
+
+// merge returns the filter with the settings of override replacing its own.
+func (f changeFilter) merge(override changeFilter) changeFilter {
+	if override.deadband != nil {
+		f.deadband = override.deadband
+	}
+	if override.deadbandPercent != nil {
+		f.deadbandPercent = override.deadbandPercent
+	}
+	if override.heartbeat > 0 {
+		f.heartbeat = override.heartbeat
+	}
+	return f
+}
+
+// changed reports whether the value differs from the last published value by more than the deadbands,
+// the values are compared for equality if no deadband applies.
+func (f changeFilter) changed(valueType string, last interface{}, value string) bool {
+	if (f.deadband == nil && f.deadbandPercent == nil) || !isNumeric(valueType) {
+		return last != value
+	}
+	lastValue, err := strconv.ParseFloat(fmt.Sprint(last), 64)
+	if err != nil {
+		return last != value
+	}
+	newValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return last != value
+	}
+
+	diff := math.Abs(newValue - lastValue)
+	if f.deadband != nil && diff <= *f.deadband {
+		return false
+	}
+	if f.deadbandPercent != nil && diff <= math.Abs(lastValue)*(*f.deadbandPercent)/100 {
+		return false
+	}
+	return diff > 0
+}
+
+func isNumeric(valueType string) bool {
+	switch valueType {
+	case common.ValueTypeUint8, common.ValueTypeUint16, common.ValueTypeUint32, common.ValueTypeUint64,
+		common.ValueTypeInt8, common.ValueTypeInt16, common.ValueTypeInt32, common.ValueTypeInt64,
+		common.ValueTypeFloat32, common.ValueTypeFloat64:
+		return true
+	default:
+		return false
+	}
+}
+
+// resourceChangeFilters returns the filters configured in the optional ResourceProperties of the
+// device resources of the readings, with the ds-deadband, ds-deadbandpercent and ds-heartbeat keys.
+func resourceChangeFilters(readings []dtos.BaseReading, lc logger.LoggingClient) map[string]changeFilter {
+	filters := make(map[string]changeFilter)
+	for _, reading := range readings {
+		dr, ok := cache.Profiles().DeviceResource(reading.ProfileName, reading.ResourceName)
+		if !ok || len(dr.Properties.Optional) == 0 {
+			continue
+		}
+		f, err := parseChangeFilter(dr.Properties.Optional, sdkCommon.Deadband, sdkCommon.DeadbandPercent, sdkCommon.Heartbeat)
+		if err != nil {
+			lc.Warnf("AutoEvent - ignoring the OnChange settings of device resource %s: %v", reading.ResourceName, err)
+			continue
+		}
+		filters[reading.ResourceName] = f
+	}
+	return filters
+}
@@ -9,6 +9,8 @@ package autoevent
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,77 +25,235 @@ import (
 
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/application"
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/transformer"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
 )
 
 type Executor struct {
 	deviceName   string
 	sourceName   string
+	interval     string
+	cron         string
 	onChange     bool
 	lastReadings map[string]interface{}
-	duration     time.Duration
-	stop         bool
+	lastSent     time.Time
+	changeFilter changeFilter
+	aggregation  *aggregation
+	schedule     schedule
+	windows      []timeWindow
+	location     *time.Location
+	read         func(ctx context.Context, e *Executor, dic *di.Container) (*dtos.Event, errors.EdgeX)
+	startDelay   time.Duration // delays the first execution
+	readLimit    chan struct{} // limits the concurrent reads of all the executors, nil means unlimited
+	trigger      chan struct{}
+	cancel       context.CancelFunc // cancels the goroutines of the Executor
+	done         chan struct{}      // closed once the goroutines of the Executor have returned
 	mutex        *sync.Mutex
+	// the runtime status of the Executor, guarded by mutex
+	paused          bool
+	lastRun         time.Time
+	nextRun         time.Time
+	lastError       string
+	failures        int
+	eventsPublished uint64
 }
 
-// Run triggers this Executor executes the handler for the event source periodically
-func (e *Executor) Run(ctx context.Context, wg *sync.WaitGroup, buffer chan bool, dic *di.Container) {
+// Start starts executing the AutoEvent in new goroutines, until the Executor is stopped or the context
+// is done. An Executor can only be started once.
+func (e *Executor) Start(ctx context.Context, wg *sync.WaitGroup, buffer chan bool, dic *di.Container) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.cancel != nil {
+		// already started or stopped
+		return
+	}
+
+	ctx, e.cancel = context.WithCancel(ctx)
 	wg.Add(1)
-	defer wg.Done()
+	go func() {
+		defer wg.Done()
+		defer close(e.done)
+		e.run(ctx, buffer, dic)
+	}()
+}
 
+// run executes the AutoEvent on its schedule. The reads are executed one at a time, and the scheduled
+// executions missed while a read overruns are skipped.
+func (e *Executor) run(ctx context.Context, buffer chan bool, dic *di.Container) {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	if e.aggregation != nil {
+		var aggregationWg sync.WaitGroup
+		aggregationWg.Add(1)
+		go func() {
+			defer aggregationWg.Done()
+			e.runAggregation(ctx, buffer, dic)
+		}()
+		defer aggregationWg.Wait()
+	}
+
+	after := time.Now().Add(e.startDelay)
 	for {
+		next := nextScheduled(e.schedule, e.windows, e.location, after)
+		if now := time.Now(); !next.IsZero() && next.Before(now) {
+			lc.Warnf("AutoEvent - reading %s of Device %s overran its schedule, skipping the missed executions", e.sourceName, e.deviceName)
+			next = nextScheduled(e.schedule, e.windows, e.location, now)
+		}
+		if next.IsZero() {
+			lc.Errorf("AutoEvent - no next execution time of %s for Device %s can be found, stop executing it", e.sourceName, e.deviceName)
+			return
+		}
+		e.mutex.Lock()
+		e.nextRun = next
+		e.mutex.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return
-		case <-time.After(e.duration):
-			if e.stop {
-				return
+		case <-e.trigger:
+			timer.Stop()
+			lc.Debugf("AutoEvent - %s of Device %s triggered", e.sourceName, e.deviceName)
+			e.execute(ctx, buffer, dic)
+		case <-timer.C:
+			after = next
+			if e.isPaused() {
+				lc.Debugf("AutoEvent - %s of Device %s is paused", e.sourceName, e.deviceName)
+				continue
 			}
-			lc.Debugf("AutoEvent - reading %s", e.sourceName)
-			evt, err := readResource(e, dic)
+			e.execute(ctx, buffer, dic)
+		}
+	}
+}
+
+// execute reads the source of the Executor and sends the Event, unless the readings are aggregated
+// or have not changed.
+func (e *Executor) execute(ctx context.Context, buffer chan bool, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debugf("AutoEvent - reading %s", e.sourceName)
+	evt, err := e.limitedRead(ctx, dic)
+	if ctx.Err() != nil {
+		lc.Debugf("AutoEvent - %s of Device %s stopped while reading, discarding the readings", e.sourceName, e.deviceName)
+		return
+	}
+	e.recordRun(err)
+	if err != nil {
+		lc.Errorf("AutoEvent - error occurs when reading resource %s: %v", e.sourceName, err)
+		return
+	}
+
+	if evt == nil {
+		lc.Debugf("AutoEvent - no event generated when reading resource %s", e.sourceName)
+		return
+	}
+	if e.aggregation != nil {
+		e.aggregation.add(evt.Readings)
+		return
+	}
+	if e.onChange {
+		if e.compareReadings(evt.Readings, resourceChangeFilters(evt.Readings, lc)) {
+			lc.Debugf("AutoEvent - readings are the same as previous one")
+			return
+		}
+	}
+	sendEvent(evt, buffer, dic)
+	e.recordPublished()
+}
+
+// limitedRead reads the source of the Executor once a read is available under the readLimit, or returns
+// nothing if the Executor is stopped while waiting.
+func (e *Executor) limitedRead(ctx context.Context, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+	if e.readLimit != nil {
+		select {
+		case e.readLimit <- struct{}{}:
+			defer func() { <-e.readLimit }()
+		case <-ctx.Done():
+			return nil, nil
+		}
+	}
+	return e.read(ctx, e, dic)
+}
+
+// runAggregation publishes the readings aggregated by the Executor at the end of every window.
+func (e *Executor) runAggregation(ctx context.Context, buffer chan bool, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	for {
+		windowEnd := e.aggregation.schedule.next(time.Now())
+		timer := time.NewTimer(time.Until(windowEnd))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			evt, err := e.aggregatedEvent(windowEnd, dic)
 			if err != nil {
-				lc.Errorf("AutoEvent - error occurs when reading resource %s: %v", e.sourceName, err)
+				lc.Errorf("AutoEvent - %v", err)
 				continue
 			}
-
-			if evt != nil {
-				if e.onChange {
-					if e.compareReadings(evt.Readings) {
-						lc.Debugf("AutoEvent - readings are the same as previous one")
-						continue
-					}
-				}
-				// After the auto event executes a read command, it will create a goroutine to send out events.
-				// When the concurrent auto event amount becomes large, core-data might be hard to handle so many HTTP requests at the same time.
-				// The device service will get some network errors like EOF or Connection reset by peer.
-				// By adding a buffer here, the user can use the Service.AsyncBufferSize configuration to control the goroutine for sending events.
-				go func() {
-					buffer <- true
-					correlationId := uuid.NewString()
-					sdkCommon.SendEvent(evt, correlationId, dic)
-					lc.Tracef("AutoEvent - Sent new Event/Reading for '%s' source with Correlation Id '%s'", evt.SourceName, correlationId)
-					<-buffer
-				}()
-			} else {
-				lc.Debugf("AutoEvent - no event generated when reading resource %s", e.sourceName)
+			if evt == nil {
+				lc.Debugf("AutoEvent - no reading of %s to aggregate", e.sourceName)
+				continue
 			}
+			sendEvent(evt, buffer, dic)
+			e.recordPublished()
 		}
 	}
 }
 
-func readResource(e *Executor, dic *di.Container) (event *dtos.Event, err errors.EdgeX) {
-	vars := make(map[string]string, 2)
-	vars[common.Name] = e.deviceName
-	vars[common.Command] = e.sourceName
+// aggregatedEvent returns the Event of the readings aggregated over the window ending at windowEnd, or nil
+// if no reading was aggregated, and starts a new window.
+func (e *Executor) aggregatedEvent(windowEnd time.Time, dic *di.Container) (*dtos.Event, error) {
+	cvs, functions, units, err := e.aggregation.flush(windowEnd.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	if len(cvs) == 0 {
+		return nil, nil
+	}
 
-	res, err := application.GetCommand(context.Background(), e.deviceName, e.sourceName, "", true, dic)
+	evt, edgexErr := transformer.AggregatedValuesToEventDTO(cvs, units, e.deviceName, e.sourceName, dic)
+	if edgexErr != nil {
+		return nil, fmt.Errorf("failed to create the Event of the aggregated readings of %s: %w", e.sourceName, edgexErr)
+	}
+	for i := range evt.Readings {
+		if evt.Readings[i].Tags == nil {
+			evt.Readings[i].Tags = make(map[string]any)
+		}
+		evt.Readings[i].Tags[aggregationTag] = functions[i]
+		evt.Readings[i].Tags[aggregationWindowTag] = e.aggregation.window.String()
+	}
+	return evt, nil
+}
+
+// sendEvent sends the Event in a new goroutine.
+func sendEvent(evt *dtos.Event, buffer chan bool, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	// After the auto event executes a read command, it will create a goroutine to send out events.
+	// When the concurrent auto event amount becomes large, core-data might be hard to handle so many HTTP requests at the same time.
+	// The device service will get some network errors like EOF or Connection reset by peer.
+	// By adding a buffer here, the user can use the Service.AsyncBufferSize configuration to control the goroutine for sending events.
+	go func() {
+		buffer <- true
+		correlationId := uuid.NewString()
+		sdkCommon.SendEvent(evt, correlationId, dic)
+		lc.Tracef("AutoEvent - Sent new Event/Reading for '%s' source with Correlation Id '%s'", evt.SourceName, correlationId)
+		<-buffer
+	}()
+}
+
+func readResource(ctx context.Context, e *Executor, dic *di.Container) (event *dtos.Event, err errors.EdgeX) {
+	// the errors of a partial read are already logged, the readings of the other resources are still published
+	res, _, err := application.GetCommand(ctx, e.deviceName, e.sourceName, "", true, 0, "", dic)
 	if err != nil {
 		return event, err
 	}
 	return res, nil
 }
 
-func (e *Executor) compareReadings(readings []dtos.BaseReading) bool {
+// compareReadings reports whether the readings are the same as the last published ones, according to
+// the change filter of the AutoEvent overridden by the filters of the device resources. The readings
+// become the last published ones if they are not the same.
+func (e *Executor) compareReadings(readings []dtos.BaseReading, resourceFilters map[string]changeFilter) bool {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 
@@ -102,32 +262,36 @@ func (e *Executor) compareReadings(readings []dtos.BaseReading) bool {
 		return false
 	}
 
-	var result = true
+	now := time.Now()
 	for _, reading := range readings {
-		if lastReading, ok := e.lastReadings[reading.ResourceName]; ok {
-			if reading.ValueType == common.ValueTypeBinary {
-				checksum := xxhash.Checksum64(reading.BinaryValue)
-				if lastReading != checksum {
-					e.lastReadings[reading.ResourceName] = checksum
-					result = false
-				}
-			} else {
-				if lastReading != reading.Value {
-					e.lastReadings[reading.ResourceName] = reading.Value
-					result = false
-				}
+		lastReading, ok := e.lastReadings[reading.ResourceName]
+		if !ok {
+			e.renewLastReadings(readings)
+			return false
+		}
+
+		filter := e.changeFilter.merge(resourceFilters[reading.ResourceName])
+		if filter.heartbeat > 0 && now.Sub(e.lastSent) >= filter.heartbeat {
+			e.renewLastReadings(readings)
+			return false
+		}
+		if reading.ValueType == common.ValueTypeBinary {
+			if lastReading != xxhash.Checksum64(reading.BinaryValue) {
+				e.renewLastReadings(readings)
+				return false
 			}
-		} else {
+		} else if filter.changed(reading.ValueType, lastReading, reading.Value) {
 			e.renewLastReadings(readings)
 			return false
 		}
 	}
 
-	return result
+	return true
 }
 
 func (e *Executor) renewLastReadings(readings []dtos.BaseReading) {
 	e.lastReadings = make(map[string]interface{}, len(readings))
+	e.lastSent = time.Now()
 	for _, r := range readings {
 		if r.ValueType == common.ValueTypeBinary {
 			e.lastReadings[r.ResourceName] = xxhash.Checksum64(r.BinaryValue)
@@ -137,24 +301,215 @@ func (e *Executor) renewLastReadings(readings []dtos.BaseReading) {
 	}
 }
 
-// Stop marks this Executor stopped
+// Stop cancels this Executor without waiting for its goroutines to return, a read in progress is
+// interrupted if the driver supports it and its readings are discarded
 func (e *Executor) Stop() {
-	e.stop = true
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.cancel == nil {
+		// never started, nothing to wait for
+		e.cancel = func() {}
+		close(e.done)
+		return
+	}
+	e.cancel()
 }
 
-// NewExecutor creates an Executor for an AutoEvent
-func NewExecutor(deviceName string, ae models.AutoEvent) (*Executor, errors.EdgeX) {
-	// check Frequency
-	duration, err := time.ParseDuration(ae.Interval)
+// Done returns a channel closed once this Executor is stopped and its goroutines have returned
+func (e *Executor) Done() <-chan struct{} {
+	return e.done
+}
+
+// Pause makes this Executor skip its scheduled executions until it is resumed
+func (e *Executor) Pause() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.paused = true
+}
+
+// Resume makes this Executor execute on its schedule again
+func (e *Executor) Resume() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.paused = false
+}
+
+// Trigger makes this Executor execute immediately, whether it is paused or not. A trigger is ignored
+// if the previous one is not handled yet.
+func (e *Executor) Trigger() {
+	select {
+	case e.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (e *Executor) isPaused() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.paused
+}
+
+// recordRun updates the status of this Executor with the result of an execution. The last error is
+// kept after a successful execution to help finding out why a device was not reporting.
+func (e *Executor) recordRun(err errors.EdgeX) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.lastRun = time.Now()
 	if err != nil {
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to parse AutoEvent %s duration", ae.SourceName), err)
+		e.lastError = err.Error()
+		e.failures++
+	} else {
+		e.failures = 0
+	}
+}
+
+func (e *Executor) recordPublished() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.eventsPublished++
+}
+
+// Status returns the runtime status of this Executor
+func (e *Executor) Status() sdkModels.AutoEventStatus {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	status := sdkModels.AutoEventStatus{
+		DeviceName:          e.deviceName,
+		SourceName:          e.sourceName,
+		Interval:            e.interval,
+		Schedule:            e.cron,
+		OnChange:            e.onChange,
+		Paused:              e.paused,
+		LastError:           e.lastError,
+		ConsecutiveFailures: e.failures,
+		EventsPublished:     e.eventsPublished,
+	}
+	if !e.lastRun.IsZero() {
+		status.LastRun = e.lastRun.UnixNano()
+	}
+	if !e.nextRun.IsZero() {
+		status.NextRun = e.nextRun.UnixNano()
+	}
+	return status
+}
+
+// NewExecutor creates an Executor for an AutoEvent. The options are the ds-autoevents device property
+// entry of the AutoEvent source, which may replace the Interval by a cron schedule, align the Interval
+// to the wall clock, restrict the executions to time windows, set the deadbands and heartbeat of OnChange,
+// and aggregate the readings over a window instead of publishing every reading.
+func NewExecutor(deviceName string, ae models.AutoEvent, options map[string]any) (*Executor, errors.EdgeX) {
+	location := time.Local
+	if timezone, ok := options[optionTimezone]; ok {
+		loc, err := time.LoadLocation(fmt.Sprint(timezone))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to load AutoEvent %s timezone", ae.SourceName), err)
+		}
+		location = loc
+	}
+	aligned, err := parseBoolOption(options, optionAlign)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse AutoEvent %s align option", ae.SourceName), err)
+	}
+
+	var s schedule
+	var cronExpr string
+	if expr, ok := options[optionSchedule]; ok {
+		cronExpr = fmt.Sprint(expr)
+		cron, err := parseCron(cronExpr, location)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse AutoEvent %s schedule", ae.SourceName), err)
+		}
+		s = cron
+	} else {
+		// check Frequency
+		duration, err := time.ParseDuration(ae.Interval)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to parse AutoEvent %s duration", ae.SourceName), err)
+		}
+		if duration <= 0 {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("AutoEvent %s duration must be positive", ae.SourceName), nil)
+		}
+		s = &intervalSchedule{interval: duration, aligned: aligned, location: location}
+	}
+
+	filter, err := parseChangeFilter(options, optionDeadband, optionDeadbandPercent, optionHeartbeat)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse AutoEvent %s OnChange options", ae.SourceName), err)
+	}
+
+	var aggregation *aggregation
+	if functions := stringsOption(options, optionAggregate); len(functions) > 0 {
+		window, err := time.ParseDuration(fmt.Sprint(options[optionAggregateWindow]))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse AutoEvent %s %s", ae.SourceName, optionAggregateWindow), err)
+		}
+		aggregation, err = newAggregation(functions, window, aligned, location)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to create AutoEvent %s aggregation", ae.SourceName), err)
+		}
+	}
+
+	var windows []timeWindow
+	for _, window := range stringsOption(options, optionWindows) {
+		w, err := parseTimeWindow(window)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse AutoEvent %s time windows", ae.SourceName), err)
+		}
+		windows = append(windows, w)
 	}
 
 	return &Executor{
-		deviceName: deviceName,
-		sourceName: ae.SourceName,
-		onChange:   ae.OnChange,
-		duration:   duration,
-		stop:       false,
-		mutex:      &sync.Mutex{}}, nil
+		deviceName:   deviceName,
+		sourceName:   ae.SourceName,
+		interval:     ae.Interval,
+		cron:         cronExpr,
+		onChange:     ae.OnChange,
+		changeFilter: filter,
+		aggregation:  aggregation,
+		schedule:     s,
+		windows:      windows,
+		location:     location,
+		read:         readResource,
+		trigger:      make(chan struct{}, 1),
+		done:         make(chan struct{}),
+		mutex:        &sync.Mutex{}}, nil
+}
+
+// autoEventOptions returns the options of the AutoEvent source from the ds-autoevents device property,
+// which maps the source names to their options.
+func autoEventOptions(properties map[string]any, sourceName string) map[string]any {
+	autoEvents, ok := properties[sdkCommon.AutoEventsProperty].(map[string]any)
+	if !ok {
+		return nil
+	}
+	options, _ := autoEvents[sourceName].(map[string]any)
+	return options
+}
+
+func parseBoolOption(options map[string]any, name string) (bool, error) {
+	value, ok := options[name]
+	if !ok {
+		return false, nil
+	}
+	return strconv.ParseBool(fmt.Sprint(value))
+}
+
+// stringsOption returns an option given either as a list or as a comma separated string.
+func stringsOption(options map[string]any, name string) []string {
+	var values []string
+	switch value := options[name].(type) {
+	case []any:
+		for _, v := range value {
+			values = append(values, fmt.Sprint(v))
+		}
+	case []string:
+		values = value
+	case string:
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
 }
@@ -1,17 +1,25 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2019-2021 IOTech Ltd
+// Copyright (C) 2019-2023 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
 package autoevent
 
 import (
+	"context"
 	"crypto/rand"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,7 +27,7 @@ import (
 
 func TestCompareReadings(t *testing.T) {
 	autoEvent := models.AutoEvent{SourceName: "sourceName", OnChange: true, Interval: "500ms"}
-	e, err := NewExecutor("device-test", autoEvent)
+	e, err := NewExecutor("device-test", autoEvent, nil)
 	require.NoError(t, err)
 
 	testReadings := []dtos.BaseReading{{ResourceName: "r1"}, {ResourceName: "r2"}}
@@ -70,8 +78,275 @@ func TestCompareReadings(t *testing.T) {
 
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			res := e.compareReadings(testCase.reading)
+			res := e.compareReadings(testCase.reading, nil)
 			assert.Equal(t, testCase.expected, res, "compareReading result not as expected")
 		})
 	}
 }
+
+func TestCompareReadings_Deadband(t *testing.T) {
+	autoEvent := models.AutoEvent{SourceName: "sourceName", OnChange: true, Interval: "500ms"}
+	options := map[string]any{"deadband": 0.5, "deadbandPercent": "10"}
+	e, err := NewExecutor("device-test", autoEvent, options)
+	require.NoError(t, err)
+
+	reading := func(value string) []dtos.BaseReading {
+		r := dtos.BaseReading{ResourceName: "r1", ValueType: common.ValueTypeFloat64}
+		r.Value = value
+		return []dtos.BaseReading{r}
+	}
+	exactResource := map[string]changeFilter{"r1": {deadband: new(float64), deadbandPercent: new(float64)}}
+
+	tests := []struct {
+		name            string
+		reading         []dtos.BaseReading
+		resourceFilters map[string]changeFilter
+		expected        bool
+	}{
+		{"false - lastReadings are nil", reading("1.000000e+01"), nil, false},
+		{"true - within absolute deadband", reading("1.040000e+01"), nil, true},
+		{"true - exceeds absolute but within percentage deadband", reading("1.090000e+01"), nil, true},
+		{"false - exceeds both deadbands", reading("1.110000e+01"), nil, false},
+		{"true - drift compared with the last published reading", reading("1.080000e+01"), nil, true},
+		{"false - resource deadband overrides AutoEvent deadband", reading("1.080100e+01"), exactResource, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			res := e.compareReadings(testCase.reading, testCase.resourceFilters)
+			assert.Equal(t, testCase.expected, res, "compareReading result not as expected")
+		})
+	}
+}
+
+func TestCompareReadings_Heartbeat(t *testing.T) {
+	autoEvent := models.AutoEvent{SourceName: "sourceName", OnChange: true, Interval: "500ms"}
+	e, err := NewExecutor("device-test", autoEvent, map[string]any{"heartbeat": "1m"})
+	require.NoError(t, err)
+
+	readings := []dtos.BaseReading{{ResourceName: "r1"}}
+	readings[0].ValueType = common.ValueTypeInt8
+	readings[0].Value = "1"
+
+	assert.False(t, e.compareReadings(readings, nil))
+	assert.True(t, e.compareReadings(readings, nil), "readings unchanged within heartbeat")
+
+	e.lastSent = time.Now().Add(-time.Minute)
+	assert.False(t, e.compareReadings(readings, nil), "readings must be published once the heartbeat elapsed")
+	assert.True(t, e.compareReadings(readings, nil), "heartbeat must restart on publication")
+
+	e.lastSent = time.Now().Add(-30 * time.Second)
+	resourceHeartbeat := map[string]changeFilter{"r1": {heartbeat: 10 * time.Second}}
+	assert.False(t, e.compareReadings(readings, resourceHeartbeat), "resource heartbeat overrides AutoEvent heartbeat")
+}
+
+func TestNewExecutor_InvalidChangeFilter(t *testing.T) {
+	autoEvent := models.AutoEvent{SourceName: "sourceName", OnChange: true, Interval: "500ms"}
+	for _, options := range []map[string]any{{"deadband": "-1"}, {"deadbandPercent": "abc"}, {"heartbeat": "1x"}} {
+		_, err := NewExecutor("device-test", autoEvent, options)
+		assert.Error(t, err, "options %v must be invalid", options)
+	}
+}
+
+func TestExecutor_Status(t *testing.T) {
+	autoEvent := models.AutoEvent{SourceName: "sourceName", Interval: "1s"}
+	e, err := NewExecutor("device-test", autoEvent, nil)
+	require.NoError(t, err)
+
+	status := e.Status()
+	assert.Equal(t, "device-test", status.DeviceName)
+	assert.Equal(t, "1s", status.Interval)
+	assert.Zero(t, status.LastRun)
+
+	e.recordRun(errors.NewCommonEdgeX(errors.KindServerError, "read failed", nil))
+	e.recordRun(errors.NewCommonEdgeX(errors.KindServerError, "read failed", nil))
+	status = e.Status()
+	assert.NotZero(t, status.LastRun)
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Contains(t, status.LastError, "read failed")
+
+	e.recordRun(nil)
+	e.recordPublished()
+	status = e.Status()
+	assert.Equal(t, 0, status.ConsecutiveFailures)
+	assert.Contains(t, status.LastError, "read failed", "the last error must be kept")
+	assert.Equal(t, uint64(1), status.EventsPublished)
+
+	e.Pause()
+	assert.True(t, e.Status().Paused)
+	e.Resume()
+	assert.False(t, e.Status().Paused)
+
+	// a pending trigger is not queued twice
+	e.Trigger()
+	e.Trigger()
+	assert.Len(t, e.trigger, 1)
+}
+
+func testDic() *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+	})
+}
+
+func waitDone(t *testing.T, e *Executor) {
+	select {
+	case <-e.Done():
+	case <-time.After(time.Second):
+		require.Fail(t, "the executor is not stopped")
+	}
+}
+
+func TestExecutor_Stop(t *testing.T) {
+	e, err := NewExecutor("device-test", models.AutoEvent{SourceName: "sourceName", Interval: "1h"}, nil)
+	require.NoError(t, err)
+	e.read = func(context.Context, *Executor, *di.Container) (*dtos.Event, errors.EdgeX) {
+		t.Error("the executor must not read before its interval")
+		return nil, nil
+	}
+
+	var wg sync.WaitGroup
+	e.Start(context.Background(), &wg, make(chan bool, 1), testDic())
+	e.Stop()
+	waitDone(t, e)
+	wg.Wait()
+
+	// an executor stopped before being started never runs
+	e, err = NewExecutor("device-test", models.AutoEvent{SourceName: "sourceName", Interval: "1ms"}, nil)
+	require.NoError(t, err)
+	e.Stop()
+	e.Start(context.Background(), &wg, make(chan bool, 1), testDic())
+	waitDone(t, e)
+	wg.Wait()
+}
+
+func TestExecutor_StopDuringRead(t *testing.T) {
+	e, err := NewExecutor("device-test", models.AutoEvent{SourceName: "sourceName", Interval: "10ms"}, nil)
+	require.NoError(t, err)
+	reading := make(chan struct{})
+	e.read = func(ctx context.Context, _ *Executor, _ *di.Container) (*dtos.Event, errors.EdgeX) {
+		close(reading)
+		<-ctx.Done()
+		return &dtos.Event{}, nil
+	}
+
+	var wg sync.WaitGroup
+	e.Start(context.Background(), &wg, make(chan bool, 1), testDic())
+	<-reading
+	e.Stop()
+	waitDone(t, e)
+	wg.Wait()
+
+	status := e.Status()
+	assert.Zero(t, status.LastRun, "the interrupted read must be discarded")
+	assert.Zero(t, status.EventsPublished)
+}
+
+func TestExecutor_OneReadInFlight(t *testing.T) {
+	e, err := NewExecutor("device-test", models.AutoEvent{SourceName: "sourceName", Interval: "5ms"}, nil)
+	require.NoError(t, err)
+	var inFlight, maxInFlight, reads atomic.Int32
+	e.read = func(context.Context, *Executor, *di.Container) (*dtos.Event, errors.EdgeX) {
+		n := inFlight.Add(1)
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		// overrun the interval
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+		reads.Add(1)
+		return nil, nil
+	}
+
+	var wg sync.WaitGroup
+	e.Start(context.Background(), &wg, make(chan bool, 1), testDic())
+	for i := 0; i < 20; i++ {
+		e.Trigger()
+		time.Sleep(2 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	e.Stop()
+	waitDone(t, e)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), maxInFlight.Load(), "reads of the same AutoEvent must not overlap")
+	assert.Greater(t, reads.Load(), int32(1))
+}
+
+func TestExecutor_ConcurrentControl(t *testing.T) {
+	e, err := NewExecutor("device-test", models.AutoEvent{SourceName: "sourceName", Interval: "1ms", OnChange: true}, nil)
+	require.NoError(t, err)
+	e.read = func(context.Context, *Executor, *di.Container) (*dtos.Event, errors.EdgeX) {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	e.Start(ctx, &wg, make(chan bool, 1), testDic())
+
+	var controlWg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		controlWg.Add(1)
+		go func() {
+			defer controlWg.Done()
+			for j := 0; j < 50; j++ {
+				e.Pause()
+				e.Trigger()
+				_ = e.Status()
+				e.Resume()
+				e.recordPublished()
+			}
+		}()
+	}
+	controlWg.Wait()
+
+	// cancelling the parent context stops the executor as well
+	cancel()
+	waitDone(t, e)
+	wg.Wait()
+	e.Stop()
+	assert.Equal(t, uint64(200), e.Status().EventsPublished)
+}
+
+func TestExecutor_ReadLimit(t *testing.T) {
+	readLimit := make(chan struct{}, 1)
+	var inFlight, maxInFlight, reads atomic.Int32
+	read := func(context.Context, *Executor, *di.Container) (*dtos.Event, errors.EdgeX) {
+		n := inFlight.Add(1)
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		reads.Add(1)
+		return nil, nil
+	}
+
+	var wg sync.WaitGroup
+	var executors []*Executor
+	for i := 0; i < 4; i++ {
+		e, err := NewExecutor("device-test", models.AutoEvent{SourceName: "sourceName", Interval: "2ms"}, nil)
+		require.NoError(t, err)
+		e.read = read
+		e.readLimit = readLimit
+		e.Start(context.Background(), &wg, make(chan bool, 1), testDic())
+		executors = append(executors, e)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for _, e := range executors {
+		e.Stop()
+		waitDone(t, e)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), maxInFlight.Load(), "the reads must not exceed the limit")
+	assert.Greater(t, reads.Load(), int32(1))
+	assert.Empty(t, readLimit)
+}
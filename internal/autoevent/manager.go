@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2019-2020 IOTech Ltd
+// Copyright (C) 2019-2023 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -8,15 +8,22 @@ package autoevent
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
 	"sync"
+	"time"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
 )
 
 type manager struct {
@@ -26,20 +33,51 @@ type manager struct {
 	mutex           sync.Mutex
 	autoeventBuffer chan bool
 	dic             *di.Container
+	// paused holds the source names of the paused AutoEvents of each device, so that they stay
+	// paused when the executors of the device are restarted
+	paused map[string]map[string]bool
+	// lifecycleMutex serializes the starts and restarts of the executors, so that the executors of
+	// a device are never started twice
+	lifecycleMutex sync.Mutex
+	startJitter    time.Duration
+	spreadPhases   bool
+	// phases holds the running executors of each Interval by the index of their phase, to spread the
+	// phases of the executors started later over the indexes left free by the stopped ones
+	phases    map[time.Duration]map[int]*Executor
+	readLimit chan struct{}
 }
 
+// goldenRatioConjugate spreads the phases of any number of executors evenly over their Interval, the
+// fractional parts of its multiples being evenly distributed without knowing the number of executors
+const goldenRatioConjugate = 0.6180339887498949
+
 func BootstrapHandler(
 	ctx context.Context,
 	wg *sync.WaitGroup,
 	_ startup.Timer,
 	dic *di.Container) bool {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	config := container.ConfigurationFrom(dic.Get)
 	m := &manager{
 		ctx:             ctx,
 		wg:              wg,
 		executorMap:     make(map[string][]*Executor),
+		paused:          make(map[string]map[string]bool),
 		dic:             dic,
 		autoeventBuffer: make(chan bool, config.Device.AsyncBufferSize),
+		spreadPhases:    config.Device.AutoEvents.SpreadPhases,
+		phases:          make(map[time.Duration]map[int]*Executor),
+	}
+	if jitter := config.Device.AutoEvents.StartJitter; jitter != "" {
+		d, err := time.ParseDuration(jitter)
+		if err != nil || d < 0 {
+			lc.Warnf("failed to parse AutoEvents StartJitter '%s', no jitter applied: %v", jitter, err)
+		} else {
+			m.startJitter = d
+		}
+	}
+	if config.Device.AutoEvents.MaxConcurrentReads > 0 {
+		m.readLimit = make(chan struct{}, config.Device.AutoEvents.MaxConcurrentReads)
 	}
 
 	dic.Update(di.ServiceConstructorMap{
@@ -52,50 +90,123 @@ func BootstrapHandler(
 }
 
 func (m *manager) StartAutoEvents() {
+	m.lifecycleMutex.Lock()
+	defer m.lifecycleMutex.Unlock()
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	for _, d := range cache.Devices().All() {
 		if _, ok := m.executorMap[d.Name]; !ok {
-			executors := m.triggerExecutors(d.Name, d.AutoEvents, m.dic)
+			executors := m.triggerExecutors(d, m.dic)
 			m.executorMap[d.Name] = executors
 		}
 	}
 }
 
-func (m *manager) triggerExecutors(deviceName string, autoEvents []models.AutoEvent, dic *di.Container) []*Executor {
+func (m *manager) triggerExecutors(device models.Device, dic *di.Container) []*Executor {
 	var executors []*Executor
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
-	for _, autoEvent := range autoEvents {
-		executor, err := NewExecutor(deviceName, autoEvent)
+	for _, autoEvent := range device.AutoEvents {
+		executor, err := NewExecutor(device.Name, autoEvent, autoEventOptions(device.Properties, autoEvent.SourceName))
 		if err != nil {
-			lc.Errorf("failed to create executor of AutoEvent %s for Device %s: %v", autoEvent.SourceName, deviceName, err)
+			lc.Errorf("failed to create executor of AutoEvent %s for Device %s: %v", autoEvent.SourceName, device.Name, err)
 			// skip this AutoEvent if it causes error during creation
 			continue
 		}
+		if m.paused[device.Name][autoEvent.SourceName] {
+			executor.Pause()
+		}
+		executor.startDelay = m.startDelay(executor)
+		executor.readLimit = m.readLimit
 		executors = append(executors, executor)
-		go executor.Run(m.ctx, m.wg, m.autoeventBuffer, dic)
+		executor.Start(m.ctx, m.wg, m.autoeventBuffer, dic)
 	}
 	return executors
 }
 
+// startDelay returns the delay of the first execution of the executor, made of its phase among the
+// running executors of the same Interval and of the start jitter. The executor takes the lowest free
+// phase index of its Interval until releasePhase is called.
+func (m *manager) startDelay(executor *Executor) time.Duration {
+	s, ok := executor.schedule.(*intervalSchedule)
+	if !ok || s.aligned {
+		return 0
+	}
+
+	var delay time.Duration
+	if m.spreadPhases {
+		executors, ok := m.phases[s.interval]
+		if !ok {
+			executors = make(map[int]*Executor)
+			m.phases[s.interval] = executors
+		}
+		phase := 0
+		for executors[phase] != nil {
+			phase++
+		}
+		executors[phase] = executor
+		delay = time.Duration(math.Mod(float64(phase)*goldenRatioConjugate, 1) * float64(s.interval))
+	}
+	if m.startJitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(m.startJitter))) // nolint: gosec
+	}
+	return delay
+}
+
+// releasePhase frees the phase index of the stopped executor for the executors started later.
+func (m *manager) releasePhase(executor *Executor) {
+	s, ok := executor.schedule.(*intervalSchedule)
+	if !ok {
+		return
+	}
+	for phase, e := range m.phases[s.interval] {
+		if e == executor {
+			delete(m.phases[s.interval], phase)
+		}
+	}
+	if len(m.phases[s.interval]) == 0 {
+		delete(m.phases, s.interval)
+	}
+}
+
+// RestartForDevice stops the executors of the device and waits for them to return before starting
+// the new ones, so that the old and new executors never run at the same time.
 func (m *manager) RestartForDevice(deviceName string) {
 	lc := bootstrapContainer.LoggingClientFrom(m.dic.Get)
 
-	m.StopForDevice(deviceName)
+	m.lifecycleMutex.Lock()
+	defer m.lifecycleMutex.Unlock()
+
+	for _, executor := range m.stopForDevice(deviceName) {
+		<-executor.Done()
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	d, ok := cache.Devices().ForName(deviceName)
 	if !ok {
 		lc.Errorf("failed to find device %s in cache to start AutoEvent", deviceName)
+		delete(m.paused, deviceName)
+		return
 	}
 
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-	executors := m.triggerExecutors(deviceName, d.AutoEvents, m.dic)
+	executors := m.triggerExecutors(d, m.dic)
 	m.executorMap[deviceName] = executors
 }
 
+// StopForDevice cancels the executors of the device without waiting for them to return. The paused
+// AutoEvents of the device are forgotten if it has been removed from the cache.
 func (m *manager) StopForDevice(deviceName string) {
+	m.stopForDevice(deviceName)
+	if _, ok := cache.Devices().ForName(deviceName); !ok {
+		m.mutex.Lock()
+		delete(m.paused, deviceName)
+		m.mutex.Unlock()
+	}
+}
+
+// stopForDevice cancels the executors of the device and returns them
+func (m *manager) stopForDevice(deviceName string) []*Executor {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -103,7 +214,103 @@ func (m *manager) StopForDevice(deviceName string) {
 	if ok {
 		for _, executor := range executors {
 			executor.Stop()
+			m.releasePhase(executor)
 		}
 		delete(m.executorMap, deviceName)
 	}
+	return executors
+}
+
+func (m *manager) AutoEventStatuses(deviceName string) ([]sdkModels.AutoEventStatus, errors.EdgeX) {
+	if deviceName != "" {
+		if _, ok := cache.Devices().ForName(deviceName); !ok {
+			return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("device %s not found", deviceName), nil)
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var deviceNames []string
+	if deviceName != "" {
+		deviceNames = []string{deviceName}
+	} else {
+		for name := range m.executorMap {
+			deviceNames = append(deviceNames, name)
+		}
+		sort.Strings(deviceNames)
+	}
+
+	statuses := make([]sdkModels.AutoEventStatus, 0)
+	for _, name := range deviceNames {
+		for _, executor := range m.executorMap[name] {
+			statuses = append(statuses, executor.Status())
+		}
+	}
+	return statuses, nil
+}
+
+func (m *manager) PauseAutoEvent(deviceName string, sourceName string) errors.EdgeX {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	executors, err := m.sourceExecutors(deviceName, sourceName)
+	if err != nil {
+		return err
+	}
+	for _, executor := range executors {
+		executor.Pause()
+	}
+	if m.paused[deviceName] == nil {
+		m.paused[deviceName] = make(map[string]bool)
+	}
+	m.paused[deviceName][sourceName] = true
+	return nil
+}
+
+func (m *manager) ResumeAutoEvent(deviceName string, sourceName string) errors.EdgeX {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	executors, err := m.sourceExecutors(deviceName, sourceName)
+	if err != nil {
+		return err
+	}
+	for _, executor := range executors {
+		executor.Resume()
+	}
+	delete(m.paused[deviceName], sourceName)
+	if len(m.paused[deviceName]) == 0 {
+		delete(m.paused, deviceName)
+	}
+	return nil
+}
+
+func (m *manager) TriggerAutoEvent(deviceName string, sourceName string) errors.EdgeX {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	executors, err := m.sourceExecutors(deviceName, sourceName)
+	if err != nil {
+		return err
+	}
+	for _, executor := range executors {
+		executor.Trigger()
+	}
+	return nil
+}
+
+// sourceExecutors returns the running executors of the AutoEvents of the device with the source name,
+// a device may have several AutoEvents of the same source with different intervals.
+func (m *manager) sourceExecutors(deviceName string, sourceName string) ([]*Executor, errors.EdgeX) {
+	var executors []*Executor
+	for _, executor := range m.executorMap[deviceName] {
+		if executor.sourceName == sourceName {
+			executors = append(executors, executor)
+		}
+	}
+	if len(executors) == 0 {
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("no running AutoEvent %s found for device %s", sourceName, deviceName), nil)
+	}
+	return executors, nil
 }
@@ -0,0 +1,187 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+)
+
+func TestManager_ControlAutoEvent(t *testing.T) {
+	e1, err := NewExecutor("device-1", models.AutoEvent{SourceName: "source-1", Interval: "1s"}, nil)
+	require.NoError(t, err)
+	e2, err := NewExecutor("device-1", models.AutoEvent{SourceName: "source-1", Interval: "1m"}, nil)
+	require.NoError(t, err)
+	e3, err := NewExecutor("device-0", models.AutoEvent{SourceName: "source-2", Interval: "1s"}, nil)
+	require.NoError(t, err)
+	m := &manager{
+		executorMap: map[string][]*Executor{"device-1": {e1, e2}, "device-0": {e3}},
+		paused:      make(map[string]map[string]bool),
+	}
+
+	require.NoError(t, m.PauseAutoEvent("device-1", "source-1"))
+	assert.True(t, e1.isPaused())
+	assert.True(t, e2.isPaused())
+	assert.False(t, e3.isPaused())
+	assert.True(t, m.paused["device-1"]["source-1"])
+
+	statuses, err := m.AutoEventStatuses("")
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.Equal(t, "device-0", statuses[0].DeviceName)
+	assert.False(t, statuses[0].Paused)
+	assert.True(t, statuses[1].Paused)
+	assert.True(t, statuses[2].Paused)
+
+	require.NoError(t, m.ResumeAutoEvent("device-1", "source-1"))
+	assert.False(t, e1.isPaused())
+	assert.False(t, e2.isPaused())
+	assert.Empty(t, m.paused)
+
+	require.NoError(t, m.TriggerAutoEvent("device-0", "source-2"))
+	assert.Len(t, e3.trigger, 1)
+
+	err = m.PauseAutoEvent("device-0", "source-1")
+	require.Error(t, err)
+	assert.Equal(t, errors.KindEntityDoesNotExist, errors.Kind(err))
+	err = m.TriggerAutoEvent("unknown", "source-1")
+	require.Error(t, err)
+	assert.Equal(t, errors.KindEntityDoesNotExist, errors.Kind(err))
+}
+
+func TestManager_StartDelay(t *testing.T) {
+	m := &manager{spreadPhases: true, phases: make(map[time.Duration]map[int]*Executor)}
+
+	var delays []time.Duration
+	for i := 0; i < 4; i++ {
+		e, err := NewExecutor("device-test", models.AutoEvent{SourceName: "source", Interval: "10s"}, nil)
+		require.NoError(t, err)
+		delays = append(delays, m.startDelay(e))
+	}
+	sorted := append([]time.Duration(nil), delays...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	assert.Zero(t, sorted[0])
+	for i := 1; i < len(sorted); i++ {
+		// 4 phases are at least a quarter of the golden ratio apart from each other
+		assert.Greater(t, sorted[i]-sorted[i-1], 1500*time.Millisecond, "phases %v are not spread", delays)
+		assert.Less(t, sorted[i], 10*time.Second)
+	}
+
+	// the phases of another interval are counted separately
+	e, err := NewExecutor("device-test", models.AutoEvent{SourceName: "source", Interval: "1m"}, nil)
+	require.NoError(t, err)
+	assert.Zero(t, m.startDelay(e))
+
+	// aligned and cron schedules are not delayed
+	m.startJitter = time.Second
+	e, err = NewExecutor("device-test", models.AutoEvent{SourceName: "source", Interval: "10s"}, map[string]any{"align": true})
+	require.NoError(t, err)
+	assert.Zero(t, m.startDelay(e))
+	e, err = NewExecutor("device-test", models.AutoEvent{SourceName: "source", Interval: "10s"}, map[string]any{"schedule": "* * * * *"})
+	require.NoError(t, err)
+	assert.Zero(t, m.startDelay(e))
+
+	m.spreadPhases = false
+	e, err = NewExecutor("device-test", models.AutoEvent{SourceName: "source", Interval: "10s"}, nil)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		delay := m.startDelay(e)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.Less(t, delay, time.Second)
+	}
+}
+
+func TestManager_StartDelayOfRestartedExecutors(t *testing.T) {
+	m := &manager{
+		spreadPhases: true,
+		phases:       make(map[time.Duration]map[int]*Executor),
+		executorMap:  make(map[string][]*Executor),
+	}
+	newExecutor := func(deviceName string) *Executor {
+		e, err := NewExecutor(deviceName, models.AutoEvent{SourceName: "source", Interval: "10s"}, nil)
+		require.NoError(t, err)
+		m.executorMap[deviceName] = append(m.executorMap[deviceName], e)
+		return e
+	}
+	assert.Zero(t, m.startDelay(newExecutor("device-1")))
+	second := m.startDelay(newExecutor("device-2"))
+	assert.NotZero(t, second)
+
+	// the restarted executors take the phases of the stopped ones instead of new ones
+	for i := 0; i < 10; i++ {
+		m.stopForDevice("device-1")
+		assert.Zero(t, m.startDelay(newExecutor("device-1")))
+	}
+	assert.Len(t, m.phases[10*time.Second], 2)
+	m.stopForDevice("device-2")
+	assert.Equal(t, second, m.startDelay(newExecutor("device-2")))
+
+	m.stopForDevice("device-1")
+	m.stopForDevice("device-2")
+	assert.Empty(t, m.phases)
+}
+
+func TestManager_PausedOfRemovedDevice(t *testing.T) {
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DevicesByServiceName", context.Background(), "service-test", 0, -1).Return(responses.MultiDevicesResponse{
+		Devices: []dtos.Device{{Name: "device-1", AdminState: models.Unlocked, OperatingState: models.Up, ServiceName: "service-test", ProfileName: "profile-test"}},
+	}, nil)
+	dpcMock := &clientMocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", context.Background(), "profile-test").Return(responses.DeviceProfileResponse{
+		Profile: dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "profile-test"}},
+	}, nil)
+	pwcMock := &clientMocks.ProvisionWatcherClient{}
+	pwcMock.On("ProvisionWatchersByServiceName", context.Background(), "service-test", 0, -1).Return(responses.MultiProvisionWatchersResponse{}, nil)
+	dic := testDic()
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
+		bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) interface{} {
+			return pwcMock
+		},
+	})
+	require.NoError(t, cache.InitCache("service-test", dic))
+
+	e, err := NewExecutor("device-1", models.AutoEvent{SourceName: "source-1", Interval: "1s"}, nil)
+	require.NoError(t, err)
+	m := &manager{
+		executorMap: map[string][]*Executor{"device-1": {e}},
+		paused:      map[string]map[string]bool{"device-1": {"source-1": true}, "device-2": {"source-1": true}},
+		dic:         dic,
+	}
+
+	// the AutoEvents stay paused while the device is only stopped, such as when it is locked
+	m.StopForDevice("device-1")
+	assert.Empty(t, m.executorMap)
+	assert.True(t, m.paused["device-1"]["source-1"])
+
+	require.NoError(t, cache.Devices().RemoveByName("device-1"))
+	m.StopForDevice("device-1")
+	assert.NotContains(t, m.paused, "device-1")
+
+	// the device is not restarted once removed
+	m.RestartForDevice("device-2")
+	assert.Empty(t, m.paused)
+}
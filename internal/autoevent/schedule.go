@@ -0,0 +1,390 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	optionSchedule = "schedule"
+	optionAlign    = "align"
+	optionTimezone = "timezone"
+	optionWindows  = "windows"
+
+	// maxWindowSearch bounds the search of a schedule time within the time windows
+	maxWindowSearch = 1000
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// schedule computes when an AutoEvent is executed next.
+type schedule interface {
+	// next returns the first execution time strictly after the given time.
+	next(after time.Time) time.Time
+}
+
+// intervalSchedule executes an AutoEvent at every multiple of the interval from its anchor, so that
+// the execution times do not drift. The anchor is the first time next is called, or the midnight of
+// the day if the schedule is aligned to the wall clock.
+type intervalSchedule struct {
+	interval time.Duration
+	aligned  bool
+	location *time.Location
+	anchor   time.Time
+}
+
+func (s *intervalSchedule) next(after time.Time) time.Time {
+	anchor := s.anchor
+	var limit time.Time
+	if s.aligned {
+		local := after.In(s.location)
+		anchor = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.location)
+		if s.interval < 24*time.Hour {
+			// the grid restarts every midnight if the interval does not divide a day
+			limit = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, s.location)
+		} else {
+			anchor = time.Date(1970, time.January, 1, 0, 0, 0, 0, s.location)
+		}
+	} else if anchor.IsZero() {
+		s.anchor = after
+		return after.Add(s.interval)
+	}
+
+	elapsed := after.Sub(anchor)
+	if elapsed < 0 {
+		return anchor
+	}
+	next := anchor.Add((elapsed/s.interval + 1) * s.interval)
+	if !limit.IsZero() && next.After(limit) {
+		return limit
+	}
+	return next
+}
+
+// cronSchedule executes an AutoEvent at the times matching a cron expression, each field is a bit set
+// of the matching values.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	domStar, dowStar                      bool
+	location                              *time.Location
+}
+
+// parseCron parses a cron expression of 5 fields (minute hour day-of-month month day-of-week), or 6
+// fields with a leading second field, or one of the @yearly, @monthly, @weekly, @daily and @hourly descriptors.
+func parseCron(expr string, location *time.Location) (*cronSchedule, error) {
+	if descriptor, ok := cronDescriptors[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = descriptor
+	}
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression '%s' must have 5 or 6 fields", expr)
+	}
+
+	s := &cronSchedule{location: location}
+	var err error
+	if s.second, _, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid second field of cron expression '%s': %w", expr, err)
+	}
+	if s.minute, _, err = parseField(fields[1], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute field of cron expression '%s': %w", expr, err)
+	}
+	if s.hour, _, err = parseField(fields[2], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour field of cron expression '%s': %w", expr, err)
+	}
+	if s.dom, s.domStar, err = parseField(fields[3], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field of cron expression '%s': %w", expr, err)
+	}
+	if s.month, _, err = parseField(fields[4], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month field of cron expression '%s': %w", expr, err)
+	}
+	if s.dow, s.dowStar, err = parseField(fields[5], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field of cron expression '%s': %w", expr, err)
+	}
+	// both 0 and 7 are Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseField parses a comma separated list of values, ranges and steps into a bit set, and reports
+// whether the field matches any value.
+func parseField(field string, min int, max int, names map[string]int) (uint64, bool, error) {
+	var bits uint64
+	star := field == "*" || field == "?"
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, false, fmt.Errorf("invalid step '%s'", stepPart)
+			}
+		}
+
+		var start, end int
+		if rangePart == "*" || rangePart == "?" {
+			start, end = min, max
+		} else {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseValue(startPart, min, max, names); err != nil {
+				return 0, false, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseValue(endPart, min, max, names); err != nil {
+					return 0, false, err
+				}
+			} else if hasStep {
+				end = max
+			}
+			if end < start {
+				return 0, false, fmt.Errorf("invalid range '%s'", rangePart)
+			}
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, star, nil
+}
+
+func parseValue(value string, min int, max int, names map[string]int) (int, error) {
+	if i, ok := names[strings.ToLower(value)]; ok {
+		return i, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i < min || i > max {
+		return 0, fmt.Errorf("value '%s' out of range [%d, %d]", value, min, max)
+	}
+	return i, nil
+}
+
+func (s *cronSchedule) next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Second).Add(time.Second)
+	yearLimit := t.Year() + 5
+
+	// when a field does not match, it is incremented and the lower fields are reset, the search
+	// restarts from the month whenever a field wraps around
+	reset := false
+	for t.Year() <= yearLimit {
+		for s.month&(1<<uint(t.Month())) == 0 {
+			if !reset {
+				reset = true
+				t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.location)
+			}
+			t = t.AddDate(0, 1, 0)
+		}
+
+		wrapped := false
+		for !s.dayMatches(t) {
+			if !reset {
+				reset = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+			}
+			t = t.AddDate(0, 0, 1)
+			if t.Day() == 1 {
+				wrapped = true
+				break
+			}
+		}
+		if wrapped {
+			continue
+		}
+
+		for s.hour&(1<<uint(t.Hour())) == 0 {
+			if !reset {
+				reset = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.location)
+			}
+			t = t.Add(time.Hour)
+			if t.Hour() == 0 {
+				wrapped = true
+				break
+			}
+		}
+		if wrapped {
+			continue
+		}
+
+		for s.minute&(1<<uint(t.Minute())) == 0 {
+			if !reset {
+				reset = true
+				t = t.Truncate(time.Minute)
+			}
+			t = t.Add(time.Minute)
+			if t.Minute() == 0 {
+				wrapped = true
+				break
+			}
+		}
+		if wrapped {
+			continue
+		}
+
+		for s.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			if t.Second() == 0 {
+				wrapped = true
+				break
+			}
+		}
+		if wrapped {
+			continue
+		}
+		return t
+	}
+	// the expression never matches, e.g. on the 30th of February
+	return time.Time{}
+}
+
+// dayMatches follows the cron convention that a day matches either the day-of-month or the day-of-week
+// field if both are restricted.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// timeWindow restricts the AutoEvent executions to a daily period of the given days of the week. The
+// period spans midnight if it ends before it starts, it then belongs to the day it starts.
+type timeWindow struct {
+	days  uint64
+	start int
+	end   int
+}
+
+// parseTimeWindow parses a time window such as "Mon-Fri 08:00-18:00", the days are optional.
+func parseTimeWindow(window string) (timeWindow, error) {
+	fields := strings.Fields(window)
+	w := timeWindow{days: 1<<7 - 1}
+	var period string
+	switch len(fields) {
+	case 1:
+		period = fields[0]
+	case 2:
+		days, _, err := parseField(fields[0], 0, 7, dayNames)
+		if err != nil {
+			return w, fmt.Errorf("invalid days of time window '%s': %w", window, err)
+		}
+		if days&(1<<7) != 0 {
+			days |= 1
+		}
+		w.days = days
+		period = fields[1]
+	default:
+		return w, fmt.Errorf("time window '%s' must be formatted as '[days] HH:MM-HH:MM'", window)
+	}
+
+	startPart, endPart, ok := strings.Cut(period, "-")
+	if !ok {
+		return w, fmt.Errorf("time window '%s' must be formatted as '[days] HH:MM-HH:MM'", window)
+	}
+	var err error
+	if w.start, err = parseTimeOfDay(startPart); err != nil {
+		return w, fmt.Errorf("invalid start of time window '%s': %w", window, err)
+	}
+	if w.end, err = parseTimeOfDay(endPart); err != nil {
+		return w, fmt.Errorf("invalid end of time window '%s': %w", window, err)
+	}
+	return w, nil
+}
+
+// parseTimeOfDay parses HH:MM into minutes since midnight, 24:00 is accepted as the end of the day.
+func parseTimeOfDay(value string) (int, error) {
+	hourPart, minutePart, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, fmt.Errorf("'%s' must be formatted as HH:MM", value)
+	}
+	hour, err := strconv.Atoi(hourPart)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' must be formatted as HH:MM", value)
+	}
+	minute, err := strconv.Atoi(minutePart)
+	if err != nil || minute < 0 || minute > 59 || hour < 0 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("'%s' is not a valid time of day", value)
+	}
+	return hour*60 + minute, nil
+}
+
+func (w timeWindow) contains(t time.Time) bool {
+	minutes := t.Hour()*60 + t.Minute()
+	today := w.days&(1<<uint(t.Weekday())) != 0
+	if w.start <= w.end {
+		return today && minutes >= w.start && minutes < w.end
+	}
+	yesterday := w.days&(1<<uint((t.Weekday()+6)%7)) != 0
+	return (today && minutes >= w.start) || (yesterday && minutes < w.end)
+}
+
+// nextStart returns the first start of the window after the given time.
+func (w timeWindow) nextStart(after time.Time) time.Time {
+	for d := 0; d <= 7; d++ {
+		start := time.Date(after.Year(), after.Month(), after.Day()+d, w.start/60, w.start%60, 0, 0, after.Location())
+		if w.days&(1<<uint(start.Weekday())) != 0 && start.After(after) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// nextScheduled returns the next execution time of the schedule after the given time which is
+// within one of the time windows.
+func nextScheduled(s schedule, windows []timeWindow, location *time.Location, after time.Time) time.Time {
+	next := s.next(after)
+	for i := 0; len(windows) > 0 && i < maxWindowSearch && !next.IsZero(); i++ {
+		local := next.In(location)
+		var windowStart time.Time
+		for _, w := range windows {
+			if w.contains(local) {
+				return next
+			}
+			if start := w.nextStart(local); !start.IsZero() && (windowStart.IsZero() || start.Before(windowStart)) {
+				windowStart = start
+			}
+		}
+		if windowStart.IsZero() {
+			return time.Time{}
+		}
+		next = s.next(windowStart.Add(-time.Nanosecond))
+	}
+	if len(windows) > 0 {
+		// no execution time within the windows has been found
+		return time.Time{}
+	}
+	return next
+}
@@ -0,0 +1,132 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	// a Wednesday
+	after := time.Date(2023, time.March, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{"every 15 minutes", "*/15 * * * *", time.Date(2023, time.March, 15, 10, 15, 0, 0, time.UTC)},
+		{"every 10 seconds", "*/10 * * * * *", time.Date(2023, time.March, 15, 10, 7, 40, 0, time.UTC)},
+		{"hourly", "@hourly", time.Date(2023, time.March, 15, 11, 0, 0, 0, time.UTC)},
+		{"daily at 08:30", "30 8 * * *", time.Date(2023, time.March, 16, 8, 30, 0, 0, time.UTC)},
+		{"weekdays at 09:00", "0 9 * * MON-FRI", time.Date(2023, time.March, 16, 9, 0, 0, 0, time.UTC)},
+		{"Sundays", "0 0 * * 7", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)},
+		{"first of the month", "0 0 1 * *", time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"yearly", "@yearly", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 feb *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"day of month or day of week", "0 0 20 * sat", time.Date(2023, time.March, 18, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseCron(tt.expr, time.UTC)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.next(after))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "* * * * foo"} {
+		_, err := parseCron(expr, time.UTC)
+		assert.Error(t, err, "expression '%s' must be invalid", expr)
+	}
+}
+
+func TestIntervalSchedule_Next(t *testing.T) {
+	after := time.Date(2023, time.March, 15, 10, 7, 30, 0, time.UTC)
+
+	aligned := &intervalSchedule{interval: 15 * time.Minute, aligned: true, location: time.UTC}
+	assert.Equal(t, time.Date(2023, time.March, 15, 10, 15, 0, 0, time.UTC), aligned.next(after))
+	assert.Equal(t, time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC), aligned.next(aligned.next(after)))
+
+	// the grid restarts at midnight when the interval does not divide a day
+	notDividing := &intervalSchedule{interval: 7 * time.Hour, aligned: true, location: time.UTC}
+	assert.Equal(t, time.Date(2023, time.March, 15, 14, 0, 0, 0, time.UTC), notDividing.next(after))
+	assert.Equal(t, time.Date(2023, time.March, 16, 0, 0, 0, 0, time.UTC), notDividing.next(time.Date(2023, time.March, 15, 22, 0, 0, 0, time.UTC)))
+
+	// the executions do not drift from the first one even if they are late
+	unaligned := &intervalSchedule{interval: time.Second, location: time.UTC}
+	first := unaligned.next(after)
+	assert.Equal(t, after.Add(time.Second), first)
+	assert.Equal(t, after.Add(2*time.Second), unaligned.next(first.Add(300*time.Millisecond)))
+}
+
+func TestNextScheduled_Windows(t *testing.T) {
+	businessHours, err := parseTimeWindow("Mon-Fri 08:00-18:00")
+	require.NoError(t, err)
+	overnight, err := parseTimeWindow("22:00-02:00")
+	require.NoError(t, err)
+	every15Minutes := &intervalSchedule{interval: 15 * time.Minute, aligned: true, location: time.UTC}
+
+	tests := []struct {
+		name     string
+		windows  []timeWindow
+		after    time.Time
+		expected time.Time
+	}{
+		{"within window", []timeWindow{businessHours}, time.Date(2023, time.March, 15, 10, 7, 0, 0, time.UTC), time.Date(2023, time.March, 15, 10, 15, 0, 0, time.UTC)},
+		{"window start", []timeWindow{businessHours}, time.Date(2023, time.March, 15, 17, 50, 0, 0, time.UTC), time.Date(2023, time.March, 16, 8, 0, 0, 0, time.UTC)},
+		{"weekend skipped", []timeWindow{businessHours}, time.Date(2023, time.March, 17, 18, 0, 0, 0, time.UTC), time.Date(2023, time.March, 20, 8, 0, 0, 0, time.UTC)},
+		{"overnight window", []timeWindow{overnight}, time.Date(2023, time.March, 15, 1, 50, 0, 0, time.UTC), time.Date(2023, time.March, 15, 22, 0, 0, 0, time.UTC)},
+		{"several windows", []timeWindow{businessHours, overnight}, time.Date(2023, time.March, 18, 12, 0, 0, 0, time.UTC), time.Date(2023, time.March, 18, 22, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nextScheduled(every15Minutes, tt.windows, time.UTC, tt.after))
+		})
+	}
+}
+
+func TestNewExecutor_Options(t *testing.T) {
+	autoEvent := models.AutoEvent{SourceName: "sourceName", Interval: "15m"}
+	properties := map[string]any{
+		"ds-autoevents": map[string]any{
+			"sourceName": map[string]any{
+				"schedule": "*/15 * * * *",
+				"timezone": "UTC",
+				"windows":  []any{"Mon-Fri 08:00-18:00"},
+			},
+		},
+	}
+	e, err := NewExecutor("device-test", autoEvent, autoEventOptions(properties, autoEvent.SourceName))
+	require.NoError(t, err)
+	require.IsType(t, &cronSchedule{}, e.schedule)
+	assert.Len(t, e.windows, 1)
+	assert.Equal(t, time.UTC, e.location)
+
+	e, err = NewExecutor("device-test", autoEvent, map[string]any{"align": "true"})
+	require.NoError(t, err)
+	require.IsType(t, &intervalSchedule{}, e.schedule)
+	assert.True(t, e.schedule.(*intervalSchedule).aligned)
+
+	invalidOptions := []map[string]any{
+		{"schedule": "* * *"},
+		{"timezone": "Nowhere/Nowhere"},
+		{"align": "maybe"},
+		{"windows": "Mon-Fri 8-18"},
+	}
+	for _, options := range invalidOptions {
+		_, err = NewExecutor("device-test", autoEvent, options)
+		assert.Error(t, err, "options %v must be invalid", options)
+	}
+}
@@ -25,6 +25,7 @@ type DeviceCache interface {
 	Update(device models.Device) errors.EdgeX
 	RemoveByName(name string) errors.EdgeX
 	UpdateAdminState(name string, state models.AdminState) errors.EdgeX
+	UpdateOperatingState(name string, state models.OperatingState) errors.EdgeX
 }
 
 type deviceCache struct {
@@ -139,6 +140,27 @@ func (d *deviceCache) UpdateAdminState(name string, state models.AdminState) err
 	return nil
 }
 
+// UpdateOperatingState updates the device operating state in cache by name. This method
+// is used to reflect the operating state changed by the device service itself before
+// Core Metadata calls back the UpdateHandler.
+func (d *deviceCache) UpdateOperatingState(name string, state models.OperatingState) errors.EdgeX {
+	if state != models.Up && state != models.Down && state != models.Unknown {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid OperatingState", nil)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	_, ok := d.deviceMap[name]
+	if !ok {
+		errMsg := fmt.Sprintf("failed to find Device %s in cache", name)
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	d.deviceMap[name].OperatingState = state
+	return nil
+}
+
 func CheckProfileNotUsed(profileName string) bool {
 	for _, device := range dc.deviceMap {
 		if device.ProfileName == profileName {
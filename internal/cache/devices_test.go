@@ -122,3 +122,30 @@ func Test_deviceCache_UpdateAdminState(t *testing.T) {
 		})
 	}
 }
+
+func Test_deviceCache_UpdateOperatingState(t *testing.T) {
+	newDeviceCache([]models.Device{testDevice})
+
+	tests := []struct {
+		name          string
+		deviceName    string
+		state         models.OperatingState
+		expectedError bool
+	}{
+		{"Invalid - nonexistent Device name", "nil", models.Down, true},
+		{"Invalid - invalid OperatingState", TestDevice, "INVALID", true},
+		{"Valid", TestDevice, models.Down, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dc.UpdateOperatingState(tt.deviceName, tt.state)
+			if tt.expectedError {
+				assert.NotNil(t, err)
+			} else {
+				assert.NoError(t, err)
+				device, _ := dc.ForName(tt.deviceName)
+				assert.Equal(t, tt.state, device.OperatingState)
+			}
+		})
+	}
+}
@@ -56,5 +56,8 @@ func InitCache(name string, dic *di.Container) errors.EdgeX {
 	}
 	newProvisionWatcherCache(pws)
 
+	// init reading cache
+	newReadingCache()
+
 	return nil
 }
@@ -0,0 +1,112 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+var (
+	rc *readingCache
+)
+
+type ReadingCache interface {
+	ForName(deviceName string, resourceName string, maxAge time.Duration) (*sdkModels.CommandValue, bool)
+	Add(deviceName string, cv *sdkModels.CommandValue)
+	RemoveByDeviceName(deviceName string)
+	RemoveByResourceNames(deviceName string, resourceNames ...string)
+}
+
+type cachedReading struct {
+	value    sdkModels.CommandValue
+	received time.Time
+}
+
+type readingCache struct {
+	readingMap map[string]map[string]cachedReading // key is Device name and DeviceResource name
+	mutex      sync.RWMutex
+}
+
+func newReadingCache() ReadingCache {
+	rc = &readingCache{readingMap: make(map[string]map[string]cachedReading)}
+	return rc
+}
+
+// ForName returns a copy of the last CommandValue read from the given device resource
+// if it was received no longer than maxAge ago.
+func (r *readingCache) ForName(deviceName string, resourceName string, maxAge time.Duration) (*sdkModels.CommandValue, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	reading, ok := r.readingMap[deviceName][resourceName]
+	if !ok || time.Since(reading.received) > maxAge {
+		return nil, false
+	}
+	return copyCommandValue(reading.value), true
+}
+
+// Add stores a copy of the CommandValue as the last value read from its device resource.
+func (r *readingCache) Add(deviceName string, cv *sdkModels.CommandValue) {
+	if cv == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	readings, ok := r.readingMap[deviceName]
+	if !ok {
+		readings = make(map[string]cachedReading)
+		r.readingMap[deviceName] = readings
+	}
+	readings[cv.DeviceResourceName] = cachedReading{value: *copyCommandValue(*cv), received: time.Now()}
+}
+
+// RemoveByDeviceName removes all the cached values of the specified device.
+func (r *readingCache) RemoveByDeviceName(deviceName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.readingMap, deviceName)
+}
+
+// RemoveByResourceNames removes the cached values of the specified device resources.
+func (r *readingCache) RemoveByResourceNames(deviceName string, resourceNames ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	readings, ok := r.readingMap[deviceName]
+	if !ok {
+		return
+	}
+	for _, name := range resourceNames {
+		delete(readings, name)
+	}
+	if len(readings) == 0 {
+		delete(r.readingMap, deviceName)
+	}
+}
+
+// copyCommandValue returns a copy of the CommandValue which can be transformed
+// without affecting the cached one.
+func copyCommandValue(cv sdkModels.CommandValue) *sdkModels.CommandValue {
+	if cv.Tags != nil {
+		tags := make(map[string]string, len(cv.Tags))
+		for k, v := range cv.Tags {
+			tags[k] = v
+		}
+		cv.Tags = tags
+	}
+	return &cv
+}
+
+func Readings() ReadingCache {
+	return rc
+}
@@ -0,0 +1,97 @@
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+var testCommandValue = sdkModels.CommandValue{
+	DeviceResourceName: TestDeviceResource,
+	Type:               common.ValueTypeInt32,
+	Value:              int32(123),
+	Tags:               map[string]string{"tag": "value"},
+}
+
+func Test_readingCache_ForName(t *testing.T) {
+	newReadingCache()
+	cv := testCommandValue
+	rc.Add(TestDevice, &cv)
+	time.Sleep(time.Millisecond)
+
+	tests := []struct {
+		name         string
+		deviceName   string
+		resourceName string
+		maxAge       time.Duration
+		expected     bool
+	}{
+		{"Invalid - nonexistent Device name", "nil", TestDeviceResource, time.Minute, false},
+		{"Invalid - nonexistent DeviceResource name", TestDevice, "nil", time.Minute, false},
+		{"Invalid - older than maxAge", TestDevice, TestDeviceResource, time.Microsecond, false},
+		{"Valid", TestDevice, TestDeviceResource, time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, ok := rc.ForName(tt.deviceName, tt.resourceName, tt.maxAge)
+			require.Equal(t, tt.expected, ok, "ForName returns opposite result")
+			if ok {
+				assert.Equal(t, testCommandValue, *res, "ForName returns wrong CommandValue")
+			}
+		})
+	}
+}
+
+func Test_readingCache_Add(t *testing.T) {
+	newReadingCache()
+	cv := testCommandValue
+	cv.Tags = map[string]string{"tag": "value"}
+	rc.Add(TestDevice, &cv)
+
+	// the cached value must not be affected by changes of the added or the returned value
+	cv.Value = int32(456)
+	cv.Tags["tag"] = "changed"
+	res, ok := rc.ForName(TestDevice, TestDeviceResource, time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, testCommandValue, *res)
+
+	res.Tags["tag"] = "changed"
+	res, ok = rc.ForName(TestDevice, TestDeviceResource, time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, testCommandValue, *res)
+}
+
+func Test_readingCache_RemoveByDeviceName(t *testing.T) {
+	newReadingCache()
+	cv := testCommandValue
+	rc.Add(TestDevice, &cv)
+
+	rc.RemoveByDeviceName(TestDevice)
+	_, ok := rc.ForName(TestDevice, TestDeviceResource, time.Minute)
+	assert.False(t, ok)
+}
+
+func Test_readingCache_RemoveByResourceNames(t *testing.T) {
+	newReadingCache()
+	cv := testCommandValue
+	rc.Add(TestDevice, &cv)
+	other := testCommandValue
+	other.DeviceResourceName = "other"
+	rc.Add(TestDevice, &other)
+
+	rc.RemoveByResourceNames(TestDevice, TestDeviceResource)
+	_, ok := rc.ForName(TestDevice, TestDeviceResource, time.Minute)
+	assert.False(t, ok)
+	_, ok = rc.ForName(TestDevice, other.DeviceResourceName, time.Minute)
+	assert.True(t, ok)
+}
@@ -0,0 +1,273 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/eventbuffer"
+)
+
+const (
+	defaultBatchMaxEvents  = 100
+	defaultBatchMaxLatency = 100 * time.Millisecond
+	compressionGzip        = "gzip"
+	compressionZstd        = "zstd"
+)
+
+var eventBatcher atomic.Pointer[batcher]
+
+// batcher accumulates the Events of all the devices of the service and publishes them as a single envelope
+// on the batch topic of the service once the batch is full or its oldest Event has waited for MaxLatency.
+// The Events of different content types are batched apart. The full batches are queued and published in
+// order by a single caller at a time, without holding the mutex.
+type batcher struct {
+	topic       string
+	maxEvents   int
+	maxSize     int
+	maxLatency  time.Duration
+	compression string
+	encoder     *zstd.Encoder
+	dic         *di.Container
+	mutex       sync.Mutex
+	batches     map[string]*eventBatch
+	pending     []*eventBatch
+	publishing  bool
+	idle        *sync.Cond
+}
+
+type eventBatch struct {
+	topic       string
+	contentType string
+	entries     []eventbuffer.Entry
+	size        int
+	timer       *time.Timer
+}
+
+// InitializeEventBatching enables the batching publisher if configured, the pending batches are
+// published when the service stops.
+func InitializeEventBatching(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) errors.EdgeX {
+	configuration := container.ConfigurationFrom(dic.Get)
+	batchingInfo := configuration.Device.EventBatching
+	if !batchingInfo.Enabled {
+		return nil
+	}
+
+	// the batches are published on their own topic, as the subscribers of the Event topics expect a single Event
+	serviceName := container.DeviceServiceFrom(dic.Get).Name
+	b := &batcher{
+		topic:       common.BuildTopic(configuration.MessageBus.GetBaseTopicPrefix(), common.EventsPublishTopic, EventBatchPrefix, serviceName),
+		maxEvents:   batchingInfo.MaxEvents,
+		maxLatency:  defaultBatchMaxLatency,
+		compression: batchingInfo.Compression,
+		dic:         dic,
+		batches:     make(map[string]*eventBatch),
+	}
+	b.idle = sync.NewCond(&b.mutex)
+	if b.maxEvents <= 0 {
+		b.maxEvents = defaultBatchMaxEvents
+	}
+	// the batch has to satisfy MaxEventSize as well
+	for _, maxSize := range []int64{batchingInfo.MaxSize, configuration.MaxEventSize} {
+		if maxSize > 0 && (b.maxSize == 0 || int(maxSize*1024) < b.maxSize) {
+			b.maxSize = int(maxSize * 1024)
+		}
+	}
+	if batchingInfo.MaxLatency != "" {
+		duration, err := time.ParseDuration(batchingInfo.MaxLatency)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to parse EventBatching MaxLatency '%s'", batchingInfo.MaxLatency)
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, err)
+		}
+		b.maxLatency = duration
+	}
+	switch b.compression {
+	case "", compressionGzip:
+	case compressionZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindServerError, "failed to create zstd encoder", err)
+		}
+		b.encoder = encoder
+	default:
+		errMsg := fmt.Sprintf("unsupported EventBatching Compression '%s', must be %s or %s", b.compression, compressionGzip, compressionZstd)
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+	eventBatcher.Store(b)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		eventBatcher.Store(nil)
+		b.flushAll()
+	}()
+	return nil
+}
+
+// add appends the Event to the batch of its content type, the batch is published first if the Event
+// would exceed its size limit. It returns false if the Event alone exceeds the size limit of a batch,
+// the Event has to be published without batching then.
+func (b *batcher) add(entry eventbuffer.Entry) bool {
+	// a batch holds the array brackets along with the Events
+	if b.maxSize > 0 && len(entry.Payload)+2 > b.maxSize {
+		return false
+	}
+
+	b.mutex.Lock()
+	key := entry.ContentType
+	batch, ok := b.batches[key]
+	if ok && b.maxSize > 0 && batch.size+len(entry.Payload)+1 > b.maxSize {
+		b.flush(key, batch)
+		ok = false
+	}
+	if !ok {
+		batch = &eventBatch{topic: b.topic, contentType: entry.ContentType, size: 1}
+		b.batches[key] = batch
+		batch.timer = time.AfterFunc(b.maxLatency, func() {
+			b.mutex.Lock()
+			if b.batches[key] == batch {
+				b.flush(key, batch)
+			}
+			b.mutex.Unlock()
+			b.publishPending()
+		})
+	}
+
+	batch.entries = append(batch.entries, entry)
+	batch.size += len(entry.Payload) + 1
+	if len(batch.entries) >= b.maxEvents {
+		b.flush(key, batch)
+	}
+	b.mutex.Unlock()
+	b.publishPending()
+	return true
+}
+
+// flushAll publishes the pending batches and waits until they are all published.
+func (b *batcher) flushAll() {
+	b.mutex.Lock()
+	for key, batch := range b.batches {
+		b.flush(key, batch)
+	}
+	b.mutex.Unlock()
+	b.publishPending()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for b.publishing || len(b.pending) > 0 {
+		b.idle.Wait()
+	}
+}
+
+// flush takes the batch out and queues it for publishing, the caller must hold the mutex.
+func (b *batcher) flush(key string, batch *eventBatch) {
+	batch.timer.Stop()
+	delete(b.batches, key)
+	b.pending = append(b.pending, batch)
+}
+
+// publishPending publishes the queued batches unless another caller is already publishing them, so that
+// the batches of a topic are published in order. The mutex is released while a batch is published.
+func (b *batcher) publishPending() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.publishing {
+		return
+	}
+
+	b.publishing = true
+	lc := bootstrapContainer.LoggingClientFrom(b.dic.Get)
+	for len(b.pending) > 0 {
+		batch := b.pending[0]
+		b.pending = b.pending[1:]
+		b.mutex.Unlock()
+
+		entry, err := b.batchEntry(batch)
+		if err != nil {
+			lc.Errorf("Failed to encode the batch of %d events for topic %s: %v", len(batch.entries), batch.topic, err)
+		} else if publishEntry(entry, b.dic) {
+			lc.Debugf("Batch of %d events published to MessageBus on topic: %s", entry.Events, entry.Topic)
+		}
+
+		b.mutex.Lock()
+	}
+	b.publishing = false
+	b.idle.Broadcast()
+}
+
+// batchEntry encodes the Events of the batch as an array of AddEventRequests and compresses it.
+func (b *batcher) batchEntry(batch *eventBatch) (eventbuffer.Entry, error) {
+	entry := eventbuffer.Entry{
+		Topic:         batch.topic,
+		CorrelationID: batch.entries[0].CorrelationID,
+		ContentType:   batch.contentType,
+		QueryParams:   map[string]string{EventCount: strconv.Itoa(len(batch.entries))},
+		Origin:        batch.entries[0].Origin,
+		Events:        len(batch.entries),
+	}
+	for _, e := range batch.entries {
+		entry.Readings += e.Readings
+	}
+
+	var payload []byte
+	if batch.contentType == common.ContentTypeCBOR {
+		items := make([]cbor.RawMessage, len(batch.entries))
+		for i, e := range batch.entries {
+			items[i] = e.Payload
+		}
+		encoded, err := cbor.Marshal(items)
+		if err != nil {
+			return entry, err
+		}
+		payload = encoded
+	} else {
+		payload = make([]byte, 0, batch.size)
+		payload = append(payload, '[')
+		for i, e := range batch.entries {
+			if i > 0 {
+				payload = append(payload, ',')
+			}
+			payload = append(payload, e.Payload...)
+		}
+		payload = append(payload, ']')
+	}
+
+	switch b.compression {
+	case compressionGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(payload); err != nil {
+			return entry, err
+		}
+		if err := writer.Close(); err != nil {
+			return entry, err
+		}
+		payload = buf.Bytes()
+		entry.QueryParams[ContentEncoding] = compressionGzip
+	case compressionZstd:
+		payload = b.encoder.EncodeAll(payload, nil)
+		entry.QueryParams[ContentEncoding] = compressionZstd
+	}
+	entry.Payload = payload
+	return entry, nil
+}
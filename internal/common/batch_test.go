@@ -0,0 +1,233 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+	msgMocks "github.com/edgexfoundry/go-mod-messaging/v3/messaging/mocks"
+	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+)
+
+func batchingDic(maxEventSize int64, batching config.EventBatchingInfo, mc *msgMocks.MessageClient) *di.Container {
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				MaxEventSize: maxEventSize,
+				Device:       config.DeviceInfo{EventBatching: batching},
+			}
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return mc
+		},
+	})
+	InitializeSentMetrics(logger.NewMockClient(), dic)
+	return dic
+}
+
+func TestSendEvent_Batching(t *testing.T) {
+	event := buildEvent()
+	req := requests.NewAddEventRequest(event)
+	eventBytes, _, err := req.Encode()
+	require.NoError(t, err)
+	// room for two Events in a batch
+	maxEventSize := int64((2*len(eventBytes)+3)/1024 + 1)
+
+	tests := []struct {
+		name            string
+		events          int
+		maxEvents       int
+		maxEventSize    int64
+		compression     string
+		expectedBatches []int
+	}{
+		{"Valid, batch full", 4, 2, 0, "", []int{2, 2}},
+		{"Valid, pending batch published on stop", 3, 2, 0, "", []int{2, 1}},
+		{"Valid, gzip compression", 2, 2, 0, compressionGzip, []int{2}},
+		{"Valid, zstd compression", 2, 2, 0, compressionZstd, []int{2}},
+		{"Valid, batch limited by MaxEventSize", 6, 10, maxEventSize, "", []int{2, 2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcMock := &msgMocks.MessageClient{}
+			var envelopes []types.MessageEnvelope
+			var topics []string
+			mcMock.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				envelopes = append(envelopes, args.Get(0).(types.MessageEnvelope))
+				topics = append(topics, args.String(1))
+			}).Return(nil)
+
+			dic := batchingDic(tt.maxEventSize, config.EventBatchingInfo{
+				Enabled:     true,
+				MaxEvents:   tt.maxEvents,
+				MaxLatency:  "1h",
+				Compression: tt.compression,
+			}, mcMock)
+			ctx, cancel := context.WithCancel(context.Background())
+			wg := &sync.WaitGroup{}
+			require.NoError(t, InitializeEventBatching(ctx, wg, dic))
+
+			for i := 0; i < tt.events; i++ {
+				SendEvent(&event, testUUIDString, dic)
+			}
+			cancel()
+			wg.Wait()
+			require.Nil(t, eventBatcher.Load())
+
+			require.Len(t, envelopes, len(tt.expectedBatches))
+			for i, envelope := range envelopes {
+				payload := envelope.Payload
+				switch tt.compression {
+				case compressionGzip:
+					reader, err := gzip.NewReader(bytes.NewReader(payload))
+					require.NoError(t, err)
+					payload, err = io.ReadAll(reader)
+					require.NoError(t, err)
+				case compressionZstd:
+					decoder, err := zstd.NewReader(nil)
+					require.NoError(t, err)
+					payload, err = decoder.DecodeAll(payload, nil)
+					require.NoError(t, err)
+				}
+				if tt.compression != "" {
+					assert.Equal(t, tt.compression, envelope.QueryParams[ContentEncoding])
+				} else {
+					assert.NotContains(t, envelope.QueryParams, ContentEncoding)
+				}
+
+				var batch []requests.AddEventRequest
+				require.NoError(t, json.Unmarshal(payload, &batch))
+				assert.Len(t, batch, tt.expectedBatches[i])
+				assert.Equal(t, strconv.Itoa(tt.expectedBatches[i]), envelope.QueryParams[EventCount])
+				assert.Equal(t, event.Id, batch[0].Event.Id)
+				assert.Equal(t, testUUIDString, envelope.CorrelationID)
+				// the batches are kept away from the subscribers of the Event topics
+				assert.Equal(t, "edgex/events/batch/"+TestDeviceService, topics[i])
+				if tt.maxEventSize > 0 {
+					assert.LessOrEqual(t, len(envelope.Payload), int(tt.maxEventSize*1024))
+				}
+			}
+			assert.Equal(t, int64(tt.events), eventsSent.Count())
+			assert.Equal(t, int64(tt.events), readingsSent.Count())
+		})
+	}
+}
+
+func TestSendEvent_BatchingDevices(t *testing.T) {
+	event := buildEvent()
+	otherEvent := buildEvent()
+	otherEvent.DeviceName = "otherDevice"
+	mcMock := &msgMocks.MessageClient{}
+	mcMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	dic := batchingDic(0, config.EventBatchingInfo{Enabled: true, MaxEvents: 2, MaxLatency: "1h"}, mcMock)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	require.NoError(t, InitializeEventBatching(ctx, wg, dic))
+
+	// the Events of the different devices share the batch of the service
+	SendEvent(&event, testUUIDString, dic)
+	SendEvent(&otherEvent, testUUIDString, dic)
+	cancel()
+	wg.Wait()
+
+	mcMock.AssertNumberOfCalls(t, "Publish", 1)
+	envelope := mcMock.Calls[0].Arguments.Get(0).(types.MessageEnvelope)
+	assert.Equal(t, "edgex/events/batch/"+TestDeviceService, mcMock.Calls[0].Arguments.String(1))
+	var batch []requests.AddEventRequest
+	require.NoError(t, json.Unmarshal(envelope.Payload, &batch))
+	require.Len(t, batch, 2)
+	assert.Equal(t, TestDeviceWithTags, batch[0].Event.DeviceName)
+	assert.Equal(t, "otherDevice", batch[1].Event.DeviceName)
+}
+
+func TestSendEvent_BatchingOversizeEvent(t *testing.T) {
+	event := buildEvent()
+	mcMock := &msgMocks.MessageClient{}
+	mcMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	dic := batchingDic(0, config.EventBatchingInfo{Enabled: true, MaxEvents: 2, MaxSize: 1, MaxLatency: "1h"}, mcMock)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	require.NoError(t, InitializeEventBatching(ctx, wg, dic))
+
+	// the Event larger than a batch is published on its Event topic right away
+	SendEvent(&event, testUUIDString, dic)
+	mcMock.AssertNumberOfCalls(t, "Publish", 1)
+	cancel()
+	wg.Wait()
+
+	mcMock.AssertNumberOfCalls(t, "Publish", 1)
+	envelope := mcMock.Calls[0].Arguments.Get(0).(types.MessageEnvelope)
+	assert.Equal(t, "edgex/events/device/"+TestDeviceService+"/"+TestProfile+"/"+TestDeviceWithTags+"/"+TestDeviceCommandWithTags, mcMock.Calls[0].Arguments.String(1))
+	assert.NotContains(t, envelope.QueryParams, EventCount)
+	var req requests.AddEventRequest
+	require.NoError(t, json.Unmarshal(envelope.Payload, &req))
+	assert.Equal(t, event.Id, req.Event.Id)
+}
+
+func TestSendEvent_BatchingPublishesUnlocked(t *testing.T) {
+	event := buildEvent()
+	publishing := make(chan struct{}, 3)
+	published := make(chan string, 3)
+	release := make(chan struct{})
+	mcMock := &msgMocks.MessageClient{}
+	mcMock.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		publishing <- struct{}{}
+		<-release
+		published <- args.Get(0).(types.MessageEnvelope).CorrelationID
+	}).Return(nil)
+
+	dic := batchingDic(0, config.EventBatchingInfo{Enabled: true, MaxEvents: 1, MaxLatency: "1h"}, mcMock)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	require.NoError(t, InitializeEventBatching(ctx, wg, dic))
+
+	// the first batch blocks in Publish, the next Events are batched without waiting for it
+	go SendEvent(&event, "1", dic)
+	<-publishing
+	done := make(chan struct{})
+	go func() {
+		SendEvent(&event, "2", dic)
+		SendEvent(&event, "3", dic)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SendEvent blocked by the batch being published")
+	}
+
+	// the queued batches are published in order once the first one is
+	close(release)
+	cancel()
+	wg.Wait()
+	require.Len(t, published, 3)
+	assert.Equal(t, "1", <-published)
+	assert.Equal(t, "2", <-published)
+	assert.Equal(t, "3", <-published)
+}
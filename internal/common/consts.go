@@ -8,9 +8,78 @@
 
 package common
 
+import "github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+
 const (
 	URLRawQuery       = "urlRawQuery"
 	SDKReservedPrefix = "ds-"
+	// MaxAge is the reserved query parameter of GET commands to accept a cached reading
+	// younger than the given duration instead of reading the device
+	MaxAge = SDKReservedPrefix + "maxage"
+	// RetryMaxAttempts and RetryBackoff are the protocol properties of a device, or the attributes of a
+	// device resource, overriding the MaxAttempts and InitialBackoff of the configured Retry policy
+	RetryMaxAttempts = SDKReservedPrefix + "retrymaxattempts"
+	RetryBackoff     = SDKReservedPrefix + "retrybackoff"
+	// ContentEncoding and EventCount are set in the QueryParams of the MessageBus envelopes of batched
+	// Events, to the compression of the payload and to the number of Events in the payload array
+	ContentEncoding = SDKReservedPrefix + "contentencoding"
+	EventCount      = SDKReservedPrefix + "eventcount"
+	// AutoEventsProperty is the device property mapping the AutoEvent source names to their scheduling
+	// options, which cannot be expressed with the AutoEvent Interval
+	AutoEventsProperty = SDKReservedPrefix + "autoevents"
+	// Deadband, DeadbandPercent and Heartbeat are the optional ResourceProperties overriding the OnChange
+	// settings of the AutoEvents for a device resource
+	Deadband        = SDKReservedPrefix + "deadband"
+	DeadbandPercent = SDKReservedPrefix + "deadbandpercent"
+	Heartbeat       = SDKReservedPrefix + "heartbeat"
+	// Transforms is the attribute of a device resource listing the transforms applied to its numeric
+	// values after the ones of the ResourceProperties, in order on reads and inverted in reverse order on writes
+	Transforms = SDKReservedPrefix + "transforms"
+	// Units is the query parameter of commands requesting the units of the values read or written, and the
+	// device property mapping the device resource names or the measured quantities to their target units
+	Units = SDKReservedPrefix + "units"
+	// AllowedValues, Pattern, MaxLength, Step and Schema are the optional ResourceProperties constraining the
+	// values written to a device resource, in addition to its Minimum and Maximum
+	AllowedValues = SDKReservedPrefix + "allowedvalues"
+	Pattern       = SDKReservedPrefix + "pattern"
+	MaxLength     = SDKReservedPrefix + "maxlength"
+	Step          = SDKReservedPrefix + "step"
+	Schema        = SDKReservedPrefix + "schema"
+	// QualityTag is the reading tag holding the Quality of the CommandValue of the reading
+	QualityTag = SDKReservedPrefix + "quality"
+	// Verify is the query parameter of SET commands, and the attribute of a device resource, requesting the
+	// written values to be read back and compared, and VerifyTolerance is the attribute of a device resource
+	// setting how much the numbers read back may differ from the written ones
+	Verify          = SDKReservedPrefix + "verify"
+	VerifyTolerance = SDKReservedPrefix + "verifytolerance"
+	// Transactional is the query parameter of SET commands requesting the previous values of the written
+	// device resources to be written back if the write or its verification fails
+	Transactional = SDKReservedPrefix + "transactional"
+	// DryRun is the query parameter of SET commands requesting the values to be validated and transformed
+	// without being written to the device
+	DryRun = SDKReservedPrefix + "dryrun"
+	// Arm is the tag of a device command, or the attribute of a device resource, requiring its SET commands
+	// to be armed before being operated, and ArmToken is the query parameter of SET commands presenting the
+	// arming token returned by the SET command which armed it
+	Arm      = SDKReservedPrefix + "arm"
+	ArmToken = SDKReservedPrefix + "armtoken"
+)
+
+const (
+	// ApiBatchCommandRoute is the REST route to read multiple device commands in one request
+	ApiBatchCommandRoute = common.ApiDeviceRoute + "/command/batch"
+	// BatchCommandTopic is appended to the command request topic of the device service
+	// to read multiple device commands in one MessageBus request
+	BatchCommandTopic = "batch"
+
+	// ApiAutoEventRoute and ApiAutoEventDeviceNameRoute are the REST routes to query the runtime status
+	// of the AutoEvents of all the devices or of a device, and the other routes pause, resume or trigger
+	// an AutoEvent of a device without updating the device in Core Metadata
+	ApiAutoEventRoute           = common.ApiBase + "/autoevent"
+	ApiAutoEventDeviceNameRoute = ApiAutoEventRoute + "/" + common.Device + "/" + common.Name + "/{" + common.Name + "}"
+	ApiAutoEventPauseRoute      = ApiAutoEventDeviceNameRoute + "/source/{" + common.SourceName + "}/pause"
+	ApiAutoEventResumeRoute     = ApiAutoEventDeviceNameRoute + "/source/{" + common.SourceName + "}/resume"
+	ApiAutoEventTriggerRoute    = ApiAutoEventDeviceNameRoute + "/source/{" + common.SourceName + "}/trigger"
 )
 
 // SDKVersion indicates the version of the SDK - will be overwritten by build
@@ -0,0 +1,166 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	gometrics "github.com/rcrowley/go-metrics"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/eventbuffer"
+)
+
+const (
+	eventBufferDepthName          = "EventBufferDepth"
+	eventBufferDroppedName        = "EventBufferDropped"
+	defaultEventBufferReplayEvery = 5 * time.Second
+)
+
+var eventBuffer atomic.Pointer[eventbuffer.Buffer]
+var eventBufferReplaying atomic.Bool
+var eventBufferDepth gometrics.Gauge
+var eventBufferDropped gometrics.Counter
+
+// InitializeEventBuffer opens the store-and-forward Event buffer if it is enabled and starts
+// replaying the Events buffered by a previous run.
+func InitializeEventBuffer(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	bufferInfo := container.ConfigurationFrom(dic.Get).Device.EventBuffer
+	if !bufferInfo.Enabled {
+		return nil
+	}
+	if bufferInfo.Directory == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "EventBuffer Directory must be specified", nil)
+	}
+
+	var maxAge time.Duration
+	if bufferInfo.MaxAge != "" {
+		duration, err := time.ParseDuration(bufferInfo.MaxAge)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to parse EventBuffer MaxAge '%s'", bufferInfo.MaxAge)
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, err)
+		}
+		maxAge = duration
+	}
+	replayInterval := defaultEventBufferReplayEvery
+	if bufferInfo.ReplayInterval != "" {
+		duration, err := time.ParseDuration(bufferInfo.ReplayInterval)
+		if err != nil || duration <= 0 {
+			errMsg := fmt.Sprintf("invalid EventBuffer ReplayInterval '%s'", bufferInfo.ReplayInterval)
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, err)
+		}
+		replayInterval = duration
+	}
+
+	buffer, err := eventbuffer.NewBuffer(bufferInfo.Directory, bufferInfo.MaxSize*1024, maxAge)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, "failed to open EventBuffer", err)
+	}
+
+	eventBufferDepth = gometrics.NewGauge()
+	eventBufferDropped = gometrics.NewCounter()
+	eventBufferDepth.Update(int64(buffer.Events()))
+	metricsManager := bootstrapContainer.MetricsManagerFrom(dic.Get)
+	if metricsManager != nil {
+		registerMetric(metricsManager, lc, eventBufferDepthName, eventBufferDepth)
+		registerMetric(metricsManager, lc, eventBufferDroppedName, eventBufferDropped)
+	} else {
+		lc.Warn("MetricsManager not available to register EventBuffer metrics")
+	}
+	eventBuffer.Store(buffer)
+	if buffer.Len() > 0 {
+		lc.Infof("%d Events found in the EventBuffer, they will be published once the MessageBus is available", buffer.Events())
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(replayInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				replayBufferedEvents(buffer, dic)
+			}
+		}
+	}()
+	return nil
+}
+
+// bufferingEvents reports whether the Events have to be buffered instead of being published so that
+// they are not published before the Events already in the buffer.
+func bufferingEvents() bool {
+	buffer := eventBuffer.Load()
+	return buffer != nil && (buffer.Len() > 0 || eventBufferReplaying.Load())
+}
+
+// bufferEvent stores an Event which could not be published, it returns false if the buffer is disabled.
+func bufferEvent(entry eventbuffer.Entry, dic *di.Container) bool {
+	buffer := eventBuffer.Load()
+	if buffer == nil {
+		return false
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dropped, err := buffer.Push(entry)
+	if stdErrors.Is(err, eventbuffer.ErrEntryTooLarge) {
+		lc.Errorf("Failed to buffer %d Events for topic %s, they exceed the EventBuffer MaxSize: %v", entry.Events, entry.Topic, err)
+		return false
+	}
+	if err != nil {
+		lc.Errorf("Failed to buffer event: %v", err)
+		return false
+	}
+	if dropped > 0 {
+		lc.Warnf("EventBuffer is full, %d oldest Events dropped", dropped)
+		eventBufferDropped.Inc(int64(dropped))
+	}
+	eventBufferDepth.Update(int64(buffer.Events()))
+	return true
+}
+
+// replayBufferedEvents publishes the buffered Events in origin order until the MessageBus fails again.
+func replayBufferedEvents(buffer *eventbuffer.Buffer, dic *di.Container) {
+	if buffer.Len() == 0 {
+		return
+	}
+	eventBufferReplaying.Store(true)
+	defer eventBufferReplaying.Store(false)
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	mc := bootstrapContainer.MessagingClientFrom(dic.Get)
+	published, dropped, err := buffer.Replay(func(entry eventbuffer.Entry) error {
+		if err := mc.Publish(newMessageEnvelope(entry), entry.Topic); err != nil {
+			return err
+		}
+		eventsSent.Inc(int64(entry.Events))
+		readingsSent.Inc(int64(entry.Readings))
+		return nil
+	})
+	eventBufferDepth.Update(int64(buffer.Events()))
+	if dropped > 0 {
+		lc.Warnf("%d buffered Events dropped as they exceeded the EventBuffer MaxAge", dropped)
+		eventBufferDropped.Inc(int64(dropped))
+	}
+	if published > 0 {
+		lc.Infof("%d buffered Events published to MessageBus, %d remaining", published, buffer.Events())
+	}
+	if err != nil {
+		lc.Debugf("Failed to publish buffered events to MessageBus: %v", err)
+	}
+}
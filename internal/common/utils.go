@@ -9,9 +9,12 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/eventbuffer"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	bootstrapInterfaces "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
@@ -21,6 +24,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
 
 	gometrics "github.com/rcrowley/go-metrics"
@@ -30,6 +34,7 @@ const (
 	eventsSentName           = "EventsSent"
 	readingsSentName         = "ReadingsSent"
 	DeviceServiceEventPrefix = "device"
+	EventBatchPrefix         = "batch"
 )
 
 // TODO: Refactor code in 3.0 to encapsulate this in a struct, factory func and
@@ -52,7 +57,6 @@ func UpdateOperatingState(name string, state string, lc logger.LoggingClient, dc
 func SendEvent(event *dtos.Event, correlationID string, dic *di.Container) {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	configuration := container.ConfigurationFrom(dic.Get)
-	ctx := context.WithValue(context.Background(), common.CorrelationHeader, correlationID) // nolint: staticcheck
 	req := requests.NewAddEventRequest(*event)
 
 	bytes, encoding, err := req.Encode()
@@ -66,25 +70,69 @@ func SendEvent(event *dtos.Event, correlationID string, dic *di.Container) {
 		return
 	}
 
-	sent := false
-	mc := bootstrapContainer.MessagingClientFrom(dic.Get)
-	ctx = context.WithValue(ctx, common.ContentType, encoding) // nolint: staticcheck
-	envelope := types.NewMessageEnvelope(bytes, ctx)
 	serviceName := container.DeviceServiceFrom(dic.Get).Name
 	publishTopic := common.BuildTopic(configuration.MessageBus.GetBaseTopicPrefix(), common.EventsPublishTopic, DeviceServiceEventPrefix, serviceName, event.ProfileName, event.DeviceName, common.URLEncode(event.SourceName))
-	err = mc.Publish(envelope, publishTopic)
+	entry := eventbuffer.Entry{
+		Topic:         publishTopic,
+		CorrelationID: correlationID,
+		ContentType:   encoding,
+		Origin:        event.Origin,
+		Events:        1,
+		Readings:      len(event.Readings),
+		Payload:       bytes,
+	}
+
+	if batcher := eventBatcher.Load(); batcher != nil {
+		if batcher.add(entry) {
+			lc.Debugf("Event(profileName: %s, deviceName: %s, sourceName: %s, id: %s) added to the batch of topic: %s",
+				event.ProfileName, event.DeviceName, event.SourceName, event.Id, batcher.topic)
+			return
+		}
+		lc.Debugf("Event(profileName: %s, deviceName: %s, sourceName: %s, id: %s) exceeds the EventBatching MaxSize, publishing it without batching",
+			event.ProfileName, event.DeviceName, event.SourceName, event.Id)
+	}
+
+	if publishEntry(entry, dic) {
+		lc.Debugf("Event(profileName: %s, deviceName: %s, sourceName: %s, id: %s) published to MessageBus on topic: %s",
+			event.ProfileName, event.DeviceName, event.SourceName, event.Id, publishTopic)
+	}
+}
+
+// publishEntry publishes a single Event or a batch of Events to the MessageBus and returns whether it has
+// been published. The Entry is buffered instead if the publishing fails, or if the buffered Events are
+// waiting to be published so that the Events are kept in order.
+func publishEntry(entry eventbuffer.Entry, dic *di.Container) bool {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	if bufferingEvents() && bufferEvent(entry, dic) {
+		lc.Debugf("%d Events for topic %s buffered until the buffered events are published", entry.Events, entry.Topic)
+		return false
+	}
+
+	mc := bootstrapContainer.MessagingClientFrom(dic.Get)
+	err := mc.Publish(newMessageEnvelope(entry), entry.Topic)
 	if err != nil {
+		if bufferEvent(entry, dic) {
+			lc.Warnf("Failed to publish event to MessageBus, event buffered: %s", err)
+			return false
+		}
 		lc.Errorf("Failed to publish event to MessageBus: %s", err)
-		return
+		return false
 	}
-	lc.Debugf("Event(profileName: %s, deviceName: %s, sourceName: %s, id: %s) published to MessageBus on topic: %s",
-		event.ProfileName, event.DeviceName, event.SourceName, event.Id, publishTopic)
-	sent = true
 
-	if sent {
-		eventsSent.Inc(1)
-		readingsSent.Inc(int64(len(event.Readings)))
+	eventsSent.Inc(int64(entry.Events))
+	readingsSent.Inc(int64(entry.Readings))
+	return true
+}
+
+func newMessageEnvelope(entry eventbuffer.Entry) types.MessageEnvelope {
+	ctx := context.WithValue(context.Background(), common.CorrelationHeader, entry.CorrelationID) // nolint: staticcheck
+	ctx = context.WithValue(ctx, common.ContentType, entry.ContentType)                           // nolint: staticcheck
+	envelope := types.NewMessageEnvelope(entry.Payload, ctx)
+	for k, v := range entry.QueryParams {
+		envelope.QueryParams[k] = v
 	}
+	return envelope
 }
 
 func InitializeSentMetrics(lc logger.LoggingClient, dic *di.Container) {
@@ -138,3 +186,21 @@ func AddReadingTags(reading *dtos.BaseReading) {
 		}
 	}
 }
+
+// ParseMaxAge parses the value of the ds-maxage query parameter, an empty value means
+// that cached readings are not accepted.
+func ParseMaxAge(value string) (time.Duration, errors.EdgeX) {
+	if value == "" {
+		return 0, nil
+	}
+	maxAge, err := time.ParseDuration(value)
+	if err != nil {
+		errMsg := fmt.Sprintf("invalid value '%s' of query parameter %s", value, MaxAge)
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, err)
+	}
+	if maxAge < 0 {
+		errMsg := fmt.Sprintf("query parameter %s must not be negative", MaxAge)
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+	return maxAge, nil
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
@@ -20,6 +21,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 	msgMocks "github.com/edgexfoundry/go-mod-messaging/v3/messaging/mocks"
 
@@ -343,3 +345,30 @@ func TestAddEventTags(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMaxAge(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      time.Duration
+		errorExpected bool
+	}{
+		{"valid - empty value", "", 0, false},
+		{"valid - duration", "5s", 5 * time.Second, false},
+		{"valid - zero", "0s", 0, false},
+		{"invalid - not a duration", "5", 0, true},
+		{"invalid - negative duration", "-1s", 0, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			maxAge, err := ParseMaxAge(testCase.value)
+			if testCase.errorExpected {
+				require.Error(t, err)
+				assert.Equal(t, edgexErrors.KindContractInvalid, edgexErrors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, maxAge)
+		})
+	}
+}
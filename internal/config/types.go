@@ -25,6 +25,9 @@ type WritableInfo struct {
 type Reading struct {
 	// ReadingUnits specifies whether or not to indicate the units of measure for the value in the reading
 	ReadingUnits bool
+	// FlagFailedAssertions specifies whether the value failing the Assertion of its device resource is
+	// published as a reading of Bad quality instead of failing the read.
+	FlagFailedAssertions bool
 }
 
 // DeviceInfo is a struct which contains device specific configuration settings.
@@ -53,6 +56,195 @@ type DeviceInfo struct {
 	EnableAsyncReadings bool
 	// Labels are properties applied to the device service to help with searching
 	Labels []string
+	// CommandTimeout defines how long a single ProtocolDriver read or write call may take.
+	CommandTimeout CommandTimeoutInfo
+	// BatchCommandWorkers is the maximum number of device commands of a batch read request
+	// which are executed concurrently.
+	BatchCommandWorkers int
+	// ReadCoalescing controls whether concurrent reads of the same device are merged into one ProtocolDriver call.
+	ReadCoalescing ReadCoalescingInfo
+	// AccessLimits defines how many ProtocolDriver calls may access a device concurrently and how often.
+	AccessLimits AccessLimitsInfo
+	// CircuitBreaker controls whether devices failing consecutive ProtocolDriver calls are marked DOWN and probed.
+	CircuitBreaker CircuitBreakerInfo
+	// Retry defines how failed ProtocolDriver calls are retried.
+	Retry RetryInfo
+	// EventBuffer controls whether the Events failing to be published to the MessageBus are stored and forwarded later.
+	EventBuffer EventBufferInfo
+	// EventBatching controls whether the Events published to the same topic are sent in batches.
+	EventBatching EventBatchingInfo
+	// AutoEvents controls how the AutoEvent executions of the devices are spread over time.
+	AutoEvents AutoEventsInfo
+	// PartialReads specifies whether the readings of a multi-resource read are published when some of the
+	// resources fail to be read or transformed, the failed resources being reported in the command response.
+	PartialReads bool
+	// WriteVerification defines how the values written by the SET commands are verified by reading them back.
+	WriteVerification WriteVerificationInfo
+	// Arming defines how long the SET commands requiring select-before-operate stay armed.
+	Arming ArmingInfo
+}
+
+// ArmingInfo is a struct which contains configuration of the select-before-operate of the SET commands, which
+// applies to the device commands whose ds-arm tag is true and to the device resources whose ds-arm attribute is
+// true. The first SET of such a command only arms it and returns an arming token, the second SET presenting the
+// token within the Timeout, with the same correlation ID and values, writes them.
+type ArmingInfo struct {
+	// Timeout indicates how long an arming token is valid, it defaults to 30s.
+	// It represents as a duration string.
+	Timeout string
+}
+
+// WriteVerificationInfo is a struct which contains configuration of the read-back verification of the SET
+// commands, which applies to the device resources whose ds-verify attribute is true, or to all the written
+// device resources if the ds-verify query parameter is true.
+type WriteVerificationInfo struct {
+	// Timeout indicates how long the written values are read back until they match, it defaults to 1s.
+	// It represents as a duration string.
+	Timeout string
+	// Interval indicates how long to wait between the reads back, it defaults to 100ms.
+	// It represents as a duration string.
+	Interval string
+}
+
+// AutoEventsInfo is a struct which contains configuration of the AutoEvent executors, to avoid that the
+// AutoEvents of many devices read at the same instant. StartJitter and SpreadPhases only apply to the
+// AutoEvents executed at an Interval which is not aligned to the wall clock.
+type AutoEventsInfo struct {
+	// StartJitter is the upper bound of a random delay of the first execution of every AutoEvent.
+	// It represents as a duration string, empty means no jitter.
+	StartJitter string
+	// SpreadPhases controls whether the executions of the AutoEvents sharing the same Interval are
+	// spread over the Interval instead of happening together.
+	SpreadPhases bool
+	// MaxConcurrentReads is the maximum number of AutoEvent reads executed concurrently across all the
+	// devices, 0 means unlimited. Unlike AsyncBufferSize, which limits the Events being sent, it limits
+	// the ProtocolDriver reads.
+	MaxConcurrentReads int
+}
+
+// EventBatchingInfo is a struct which contains configuration of the Event batching publisher. The Events
+// of all the devices of the service are published in batches, as an array of AddEventRequests in a single
+// MessageBus envelope, whose QueryParams hold the number of Events (ds-eventcount) and the compression of
+// the payload (ds-contentencoding). The batches are published on the <base-topic>/events/batch/<service>
+// topic instead of the Event topics, so the subscribers of the Event topics don't receive them. An Event
+// exceeding the batch size limit on its own is published on its Event topic without batching.
+type EventBatchingInfo struct {
+	// Enabled controls whether or not the Events are batched.
+	Enabled bool
+	// MaxEvents is the maximum number of Events in a batch, it defaults to 100.
+	MaxEvents int
+	// MaxSize is the maximum size in kilobytes of the uncompressed batch payload, 0 means unlimited.
+	// The batch is also limited by MaxEventSize.
+	MaxSize int64
+	// MaxLatency indicates how long an Event may wait for its batch to be published, it defaults to 100ms.
+	// It represents as a duration string.
+	MaxLatency string
+	// Compression is the algorithm compressing the batch payload, either gzip or zstd. Empty means no compression.
+	Compression string
+}
+
+// EventBufferInfo is a struct which contains configuration of the store-and-forward Event buffer.
+type EventBufferInfo struct {
+	// Enabled controls whether or not the Events failing to be published are buffered.
+	Enabled bool
+	// Directory specifies the directory in which the buffered Events are stored.
+	Directory string
+	// MaxSize is the maximum total size in kilobytes of the buffered Events, the oldest Events are
+	// dropped once it is exceeded. 0 means unlimited.
+	MaxSize int64
+	// MaxAge indicates how long an Event is kept in the buffer, the Events with an older origin are
+	// dropped instead of being published. It represents as a duration string, empty means unlimited.
+	MaxAge string
+	// ReplayInterval indicates how often the buffered Events are published again while the MessageBus
+	// is unavailable, it defaults to 5s. It represents as a duration string.
+	ReplayInterval string
+}
+
+// RetryInfo is a struct which contains the retry policy of ProtocolDriver calls. MaxAttempts and
+// InitialBackoff can be overridden per device with the ds-retrymaxattempts and ds-retrybackoff
+// protocol properties, or per device resource with the attributes of the same names. The writes
+// are only retried if RetryWrites is enabled.
+type RetryInfo struct {
+	// MaxAttempts is the maximum number of ProtocolDriver calls for a single read or write, 0 or 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff indicates how long to wait before the first retry, it defaults to 100ms.
+	// It represents as a duration string.
+	InitialBackoff string
+	// MaxBackoff is the upper bound of the wait between retries, it defaults to 5s.
+	// It represents as a duration string.
+	MaxBackoff string
+	// Multiplier is the factor the backoff grows by after each retry, it defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, of the backoff which is randomized.
+	Jitter float64
+	// RetryableErrorKinds lists the kinds of errors which are retried, it defaults to
+	// UnexpectedServerError, Communication, IOError and ServiceUnavailable.
+	RetryableErrorKinds []string
+	// RetryWrites specifies whether the ProtocolDriver writes are retried as well. They are not by default
+	// as repeating a write, e.g. an actuation, may not be safe.
+	RetryWrites bool
+}
+
+// CircuitBreakerInfo is a struct which contains configuration of the device circuit breaker.
+type CircuitBreakerInfo struct {
+	// Enabled controls whether or not the circuit breaker is enabled.
+	Enabled bool
+	// FailureThreshold is the number of consecutive failed ProtocolDriver calls after which a device is marked DOWN.
+	FailureThreshold int
+	// ProbeInterval indicates how often a device marked DOWN is read to check whether it has recovered.
+	// It represents as a duration string.
+	ProbeInterval string
+	// ProbeResources maps device profile names to the device resource or device command read to probe
+	// the devices. The first AutoEvent source or the first readable device resource is read otherwise.
+	ProbeResources map[string]string
+}
+
+// ReadCoalescingInfo is a struct which contains configuration of driver read coalescing.
+type ReadCoalescingInfo struct {
+	// Enabled controls whether or not read coalescing is enabled.
+	Enabled bool
+	// Window indicates how long the reads of a device are collected before they are sent to the driver.
+	// It represents as a duration string.
+	Window string
+}
+
+// CommandTimeoutInfo is a struct which contains the timeout configuration of ProtocolDriver calls.
+// All the values are duration strings, an empty or zero value means no timeout.
+type CommandTimeoutInfo struct {
+	// Default is the timeout applied to every device command unless overridden.
+	Default string
+	// Devices overrides Default for the devices with the given names.
+	Devices map[string]string
+	// Commands overrides Default and Devices for the device commands or device resources with the given names.
+	Commands map[string]string
+}
+
+// AccessLimitsInfo is a struct which contains the concurrency and rate limits of ProtocolDriver calls.
+// A call has to satisfy the limits of its device and of all the protocols of the device it is limited by.
+type AccessLimitsInfo struct {
+	// Default is the limit applied to every device unless overridden.
+	Default AccessLimitInfo
+	// Devices overrides Default for the devices with the given names.
+	Devices map[string]AccessLimitInfo
+	// Protocols defines limits shared by all the devices having the same value of a property of
+	// the given protocols, e.g. the devices on the same serial port or behind the same gateway.
+	Protocols map[string]AccessLimitInfo
+}
+
+// AccessLimitInfo is a struct which contains a single concurrency and rate limit.
+type AccessLimitInfo struct {
+	// MaxConcurrent is the maximum number of ProtocolDriver calls in progress at the same time, 0 means unlimited.
+	MaxConcurrent int
+	// Rate is the number of ProtocolDriver calls allowed per second, 0 means unlimited.
+	Rate float64
+	// Burst is the number of calls allowed in excess of Rate after a period of inactivity, it defaults to 1.
+	Burst int
+	// MaxWait indicates how long a call waits for the limit before it is rejected, an empty value
+	// means to wait until the command times out. It represents as a duration string.
+	MaxWait string
+	// Property is the name of the protocol property whose value identifies the devices sharing the limit.
+	// It is only used for the limits of Protocols.
+	Property string
 }
 
 // DiscoveryInfo is a struct which contains configuration of device auto discovery.
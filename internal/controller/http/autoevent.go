@@ -0,0 +1,104 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// MultiAutoEventStatusesResponse is the response of the AutoEvent status routes
+type MultiAutoEventStatusesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	AutoEvents             []sdkModels.AutoEventStatus `json:"autoEvents"`
+}
+
+// AllAutoEventStatuses returns the runtime status of the AutoEvents of all the devices
+func (c *RestController) AllAutoEventStatuses(w http.ResponseWriter, r *http.Request) {
+	c.autoEventStatuses(w, r, "", sdkCommon.ApiAutoEventRoute)
+}
+
+// AutoEventStatusesByDeviceName returns the runtime status of the AutoEvents of a device
+func (c *RestController) AutoEventStatusesByDeviceName(w http.ResponseWriter, r *http.Request) {
+	c.autoEventStatuses(w, r, mux.Vars(r)[common.Name], sdkCommon.ApiAutoEventDeviceNameRoute)
+}
+
+func (c *RestController) autoEventStatuses(w http.ResponseWriter, r *http.Request, deviceName string, route string) {
+	manager, err := c.autoEventManager()
+	if err != nil {
+		c.sendEdgexError(w, r, err, route)
+		return
+	}
+
+	statuses, err := manager.AutoEventStatuses(deviceName)
+	if err != nil {
+		c.sendEdgexError(w, r, err, route)
+		return
+	}
+
+	res := MultiAutoEventStatusesResponse{
+		BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+		AutoEvents:   statuses,
+	}
+	c.sendResponse(w, r, route, res, http.StatusOK)
+}
+
+// PauseAutoEvent pauses an AutoEvent of a device until it is resumed
+func (c *RestController) PauseAutoEvent(w http.ResponseWriter, r *http.Request) {
+	c.controlAutoEvent(w, r, sdkCommon.ApiAutoEventPauseRoute, http.StatusOK, interfaces.AutoEventManager.PauseAutoEvent)
+}
+
+// ResumeAutoEvent resumes a paused AutoEvent of a device
+func (c *RestController) ResumeAutoEvent(w http.ResponseWriter, r *http.Request) {
+	c.controlAutoEvent(w, r, sdkCommon.ApiAutoEventResumeRoute, http.StatusOK, interfaces.AutoEventManager.ResumeAutoEvent)
+}
+
+// TriggerAutoEvent executes an AutoEvent of a device immediately, the Event is published asynchronously
+func (c *RestController) TriggerAutoEvent(w http.ResponseWriter, r *http.Request) {
+	c.controlAutoEvent(w, r, sdkCommon.ApiAutoEventTriggerRoute, http.StatusAccepted, interfaces.AutoEventManager.TriggerAutoEvent)
+}
+
+func (c *RestController) controlAutoEvent(
+	w http.ResponseWriter,
+	r *http.Request,
+	route string,
+	statusCode int,
+	control func(interfaces.AutoEventManager, string, string) errors.EdgeX) {
+	vars := mux.Vars(r)
+	deviceName := vars[common.Name]
+	sourceName := vars[common.SourceName]
+
+	manager, err := c.autoEventManager()
+	if err != nil {
+		c.sendEdgexError(w, r, err, route)
+		return
+	}
+
+	if err = control(manager, deviceName, sourceName); err != nil {
+		c.sendEdgexError(w, r, err, route)
+		return
+	}
+
+	res := commonDTO.NewBaseResponse("", "", statusCode)
+	c.sendResponse(w, r, route, res, statusCode)
+}
+
+func (c *RestController) autoEventManager() (interfaces.AutoEventManager, errors.EdgeX) {
+	if c.dic.Get(container.AutoEventManagerName) == nil {
+		return nil, errors.NewCommonEdgeX(errors.KindServiceUnavailable, "AutoEvent manager is not started", nil)
+	}
+	return container.AutoEventManagerFrom(c.dic.Get), nil
+}
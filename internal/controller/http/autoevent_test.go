@@ -0,0 +1,169 @@
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// testAutoEventManager runs a single AutoEvent testResource of testDevice
+type testAutoEventManager struct {
+	paused    bool
+	triggered int
+}
+
+func (m *testAutoEventManager) StartAutoEvents()        {}
+func (m *testAutoEventManager) RestartForDevice(string) {}
+func (m *testAutoEventManager) StopForDevice(string)    {}
+
+func (m *testAutoEventManager) AutoEventStatuses(name string) ([]sdkModels.AutoEventStatus, errors.EdgeX) {
+	if name != "" && name != testDevice {
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device not found", nil)
+	}
+	return []sdkModels.AutoEventStatus{{DeviceName: testDevice, SourceName: testResource, Interval: "1s", Paused: m.paused}}, nil
+}
+
+func (m *testAutoEventManager) PauseAutoEvent(name string, sourceName string) errors.EdgeX {
+	return m.control(name, sourceName, func() { m.paused = true })
+}
+
+func (m *testAutoEventManager) ResumeAutoEvent(name string, sourceName string) errors.EdgeX {
+	return m.control(name, sourceName, func() { m.paused = false })
+}
+
+func (m *testAutoEventManager) TriggerAutoEvent(name string, sourceName string) errors.EdgeX {
+	return m.control(name, sourceName, func() { m.triggered++ })
+}
+
+func (m *testAutoEventManager) control(name string, sourceName string, f func()) errors.EdgeX {
+	if name != testDevice || sourceName != testResource {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "AutoEvent not found", nil)
+	}
+	f()
+	return nil
+}
+
+func autoEventDic(manager *testAutoEventManager) *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.AutoEventManagerName: func(get di.Get) interface{} {
+			return manager
+		},
+	})
+}
+
+func TestRestController_AutoEventStatuses(t *testing.T) {
+	controller := NewRestController(mux.NewRouter(), autoEventDic(&testAutoEventManager{}), testService)
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		expectedStatusCode int
+	}{
+		{"all devices", "", http.StatusOK},
+		{"valid - device", testDevice, http.StatusOK},
+		{"invalid - device not found", "notFound", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			route := sdkCommon.ApiAutoEventRoute
+			handler := controller.AllAutoEventStatuses
+			if testCase.deviceName != "" {
+				route = sdkCommon.ApiAutoEventRoute + "/device/name/" + testCase.deviceName
+				handler = controller.AutoEventStatusesByDeviceName
+			}
+			req, err := http.NewRequest(http.MethodGet, route, http.NoBody)
+			require.NoError(t, err)
+			if testCase.deviceName != "" {
+				req = mux.SetURLVars(req, map[string]string{common.Name: testCase.deviceName})
+			}
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(handler).ServeHTTP(recorder, req)
+
+			var res MultiAutoEventStatusesResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			assert.Equal(t, common.ApiVersion, res.ApiVersion, "API Version not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				require.Len(t, res.AutoEvents, 1)
+				assert.Equal(t, testResource, res.AutoEvents[0].SourceName)
+			}
+		})
+	}
+}
+
+func TestRestController_ControlAutoEvent(t *testing.T) {
+	manager := &testAutoEventManager{}
+	controller := NewRestController(mux.NewRouter(), autoEventDic(manager), testService)
+
+	tests := []struct {
+		name               string
+		handler            http.HandlerFunc
+		sourceName         string
+		expectedStatusCode int
+	}{
+		{"valid - pause", controller.PauseAutoEvent, testResource, http.StatusOK},
+		{"valid - trigger", controller.TriggerAutoEvent, testResource, http.StatusAccepted},
+		{"valid - resume", controller.ResumeAutoEvent, testResource, http.StatusOK},
+		{"invalid - AutoEvent not found", controller.PauseAutoEvent, "notFound", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, sdkCommon.ApiAutoEventRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{common.Name: testDevice, common.SourceName: testCase.sourceName})
+
+			recorder := httptest.NewRecorder()
+			testCase.handler.ServeHTTP(recorder, req)
+
+			var res commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+		})
+	}
+	assert.False(t, manager.paused)
+	assert.Equal(t, 1, manager.triggered)
+}
+
+func TestRestController_AutoEventManagerNotStarted(t *testing.T) {
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+	})
+	controller := NewRestController(mux.NewRouter(), dic, testService)
+
+	req, err := http.NewRequest(http.MethodGet, sdkCommon.ApiAutoEventRoute, http.NoBody)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	http.HandlerFunc(controller.AllAutoEventStatuses).ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Result().StatusCode)
+}
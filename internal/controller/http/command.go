@@ -44,7 +44,13 @@ func (c *RestController) GetCommand(w http.ResponseWriter, r *http.Request) {
 		regexCmd = false
 	}
 
-	event, err := application.GetCommand(ctx, deviceName, commandName, queryParams, regexCmd, c.dic)
+	maxAge, err := sdkCommon.ParseMaxAge(reserved.Get(sdkCommon.MaxAge))
+	if err != nil {
+		c.sendEdgexError(w, r, err, common.ApiDeviceNameCommandNameRoute)
+		return
+	}
+
+	event, resourceErrors, err := application.GetCommand(ctx, deviceName, commandName, queryParams, regexCmd, maxAge, reserved.Get(sdkCommon.Units), c.dic)
 	if err != nil {
 		c.sendEdgexError(w, r, err, common.ApiDeviceNameCommandNameRoute)
 		return
@@ -55,13 +61,29 @@ func (c *RestController) GetCommand(w http.ResponseWriter, r *http.Request) {
 		go sdkCommon.SendEvent(event, correlationId, c.dic)
 	}
 
+	// the resources which failed to be read by a partial read are listed along with the event
+	statusCode := http.StatusOK
+	if len(resourceErrors) > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+
 	// return event in http response if specified (default true)
 	if returnEvent := reserved.Get(common.ReturnEvent); returnEvent == "" || returnEvent == common.ValueTrue {
+		if len(resourceErrors) > 0 {
+			res := application.NewPartialEventResponse("", statusCode, *event, resourceErrors)
+			c.sendEventResponse(w, r, &res, statusCode)
+			return
+		}
 		res := responses.NewEventResponse("", "", http.StatusOK, *event)
-		c.sendEventResponse(w, r, res, http.StatusOK)
+		c.sendEventResponse(w, r, &res, http.StatusOK)
 		return
 	}
 
+	if len(resourceErrors) > 0 {
+		res := application.NewResourceErrorsResponse("", statusCode, resourceErrors)
+		c.sendResponse(w, r, common.ApiDeviceNameCommandNameRoute, res, statusCode)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -76,7 +98,7 @@ func (c *RestController) SetCommand(w http.ResponseWriter, r *http.Request) {
 	commandName := vars[common.Command]
 
 	// parse query parameter
-	queryParams, _, err := filterQueryParams(r.URL.RawQuery)
+	queryParams, reserved, err := filterQueryParams(r.URL.RawQuery)
 	if err != nil {
 		c.sendEdgexError(w, r, err, common.ApiDeviceNameCommandNameRoute)
 		return
@@ -88,21 +110,98 @@ func (c *RestController) SetCommand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	event, err := application.SetCommand(ctx, deviceName, commandName, queryParams, requestParamsMap, c.dic)
+	options := application.SetCommandOptions{
+		Units:         reserved.Get(sdkCommon.Units),
+		Verify:        reserved.Get(sdkCommon.Verify) == common.ValueTrue,
+		Transactional: reserved.Get(sdkCommon.Transactional) == common.ValueTrue,
+		DryRun:        reserved.Get(sdkCommon.DryRun) == common.ValueTrue,
+		ArmToken:      reserved.Get(sdkCommon.ArmToken),
+	}
+	result, err := application.SetCommand(ctx, deviceName, commandName, queryParams, options, requestParamsMap, c.dic)
 	if err != nil {
 		c.sendEdgexError(w, r, err, common.ApiDeviceNameCommandNameRoute)
 		return
 	}
 
-	if event != nil {
+	if result.Arming != nil {
+		res := application.NewArmingResponse("", http.StatusAccepted, *result.Arming)
+		c.sendResponse(w, r, common.ApiDeviceNameCommandNameRoute, res, http.StatusAccepted)
+		return
+	}
+	if options.DryRun {
+		res := application.NewDryRunResponse("", http.StatusOK, result.DryRunValues)
+		c.sendResponse(w, r, common.ApiDeviceNameCommandNameRoute, res, http.StatusOK)
+		return
+	}
+
+	if result.Event != nil {
 		correlationId := utils.FromContext(ctx, common.CorrelationHeader)
-		go sdkCommon.SendEvent(event, correlationId, c.dic)
+		go sdkCommon.SendEvent(result.Event, correlationId, c.dic)
 	}
 
 	res := commonDTO.NewBaseResponse("", "", http.StatusOK)
 	c.sendResponse(w, r, common.ApiDeviceNameCommandNameRoute, res, http.StatusOK)
 }
 
+func (c *RestController) GetBatchCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	ctx := r.Context()
+	correlationId := utils.FromContext(ctx, common.CorrelationHeader)
+
+	// parse query parameter
+	queryParams, reserved, err := filterQueryParams(r.URL.RawQuery)
+	if err != nil {
+		c.sendEdgexError(w, r, err, sdkCommon.ApiBatchCommandRoute)
+		return
+	}
+
+	regexCmd := true
+	if useRegex := reserved.Get(common.RegexCommand); useRegex == common.ValueFalse {
+		regexCmd = false
+	}
+
+	maxAge, err := sdkCommon.ParseMaxAge(reserved.Get(sdkCommon.MaxAge))
+	if err != nil {
+		c.sendEdgexError(w, r, err, sdkCommon.ApiBatchCommandRoute)
+		return
+	}
+
+	var batchRequest application.BatchCommandRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&batchRequest); decodeErr != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to parse request body", decodeErr)
+		c.sendEdgexError(w, r, edgexErr, sdkCommon.ApiBatchCommandRoute)
+		return
+	}
+	if err = batchRequest.Validate(); err != nil {
+		c.sendEdgexError(w, r, err, sdkCommon.ApiBatchCommandRoute)
+		return
+	}
+
+	results := application.GetCommands(ctx, batchRequest.Commands, queryParams, regexCmd, maxAge, reserved.Get(sdkCommon.Units), c.dic)
+
+	pushEvent := reserved.Get(common.PushEvent) == common.ValueTrue
+	returnEvent := reserved.Get(common.ReturnEvent) != common.ValueFalse
+	for i := range results {
+		if results[i].Event == nil {
+			continue
+		}
+		// push event to CoreData if specified (default false)
+		if pushEvent {
+			go sdkCommon.SendEvent(results[i].Event, correlationId, c.dic)
+		}
+		// return event in http response if specified (default true)
+		if !returnEvent {
+			results[i].Event = nil
+		}
+	}
+
+	res := application.NewBatchCommandResponse(batchRequest.RequestId, http.StatusMultiStatus, results)
+	c.sendResponse(w, r, sdkCommon.ApiBatchCommandRoute, res, http.StatusMultiStatus)
+}
+
 func parseRequestBody(req *http.Request) (map[string]interface{}, errors.EdgeX) {
 	defer req.Body.Close()
 	body, err := io.ReadAll(req.Body)
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
@@ -30,6 +31,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/application"
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
@@ -46,6 +48,7 @@ const (
 	lockedDevice      = "locked-device"
 	downedDevice      = "down-device"
 	driverErrorDevice = "driver-device"
+	timeoutDevice     = "timeout-device"
 
 	testCommand      = "test-command"
 	readOnlyCommand  = "ro-command"
@@ -56,6 +59,7 @@ const (
 	readOnlyResource  = "ro-resource"
 	writeOnlyResource = "wo-resource"
 	objectResource    = "object-resource"
+	armedResource     = "armed-resource"
 
 	testRegexResource = "^t.+-resource"
 )
@@ -90,8 +94,15 @@ func mockDic() *di.Container {
 			ServiceName:    testService,
 			ProfileName:    testProfile,
 		},
+		dtos.Device{
+			Name:           timeoutDevice,
+			AdminState:     models.Unlocked,
+			OperatingState: models.Up,
+			ServiceName:    testService,
+			ProfileName:    testProfile,
+		},
 	}
-	deviceResponse := responses.NewMultiDevicesResponse("", "", http.StatusOK, 4, devices)
+	deviceResponse := responses.NewMultiDevicesResponse("", "", http.StatusOK, uint32(len(devices)), devices)
 	profile := dtos.DeviceProfile{
 		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{
 			Name: testProfile,
@@ -126,6 +137,14 @@ func mockDic() *di.Container {
 					ReadWrite: common.ReadWrite_RW,
 				},
 			},
+			dtos.DeviceResource{
+				Name:       armedResource,
+				Attributes: map[string]any{sdkCommon.Arm: true},
+				Properties: dtos.ResourceProperties{
+					ValueType: common.ValueTypeString,
+					ReadWrite: common.ReadWrite_W,
+				},
+			},
 		},
 		DeviceCommands: []dtos.DeviceCommand{
 			dtos.DeviceCommand{
@@ -169,11 +188,16 @@ func mockDic() *di.Container {
 	mockDriver.On("HandleReadCommands", driverErrorDevice, mock.Anything, mock.Anything).Return(nil, errors.New("ProtocolDriver returned error"))
 	mockDriver.On("HandleWriteCommands", testDevice, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mockDriver.On("HandleWriteCommands", driverErrorDevice, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("ProtocolDriver returned error"))
+	mockDriver.On("HandleReadCommands", timeoutDevice, mock.Anything, mock.Anything).After(time.Second).Return([]*sdkModels.CommandValue{commandValue}, nil)
+	mockDriver.On("HandleWriteCommands", timeoutDevice, mock.Anything, mock.Anything, mock.Anything).After(time.Second).Return(nil)
 	dic := di.NewContainer(di.ServiceConstructorMap{
 		container.ConfigurationName: func(get di.Get) any {
 			return &config.ConfigurationStruct{
 				Device: config.DeviceInfo{
 					MaxCmdOps: 1,
+					CommandTimeout: config.CommandTimeoutInfo{
+						Devices: map[string]string{timeoutDevice: "50ms"},
+					},
 				},
 			}
 		},
@@ -231,6 +255,7 @@ func TestRestController_GetCommand(t *testing.T) {
 		{"invalid - device command is write-only", testDevice, writeOnlyCommand, http.StatusMethodNotAllowed},
 		{"invalid - device command resource operations exceed MaxCmdOps", testDevice, exceedCommand, http.StatusInternalServerError},
 		{"invalid - error in ProtocolDriver implementation", driverErrorDevice, testResource, http.StatusInternalServerError},
+		{"invalid - ProtocolDriver call timed out", timeoutDevice, testResource, http.StatusServiceUnavailable},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -323,6 +348,79 @@ func TestRestController_GetCommand_ReturnEvent(t *testing.T) {
 	assert.Empty(t, recorder.Body.Bytes())
 }
 
+func TestRestController_GetCommand_PartialRead(t *testing.T) {
+	commandValue := &sdkModels.CommandValue{
+		DeviceResourceName: testResource,
+		Type:               common.ValueTypeString,
+		Value:              "test",
+	}
+	partialErr := sdkModels.NewPartialReadError()
+	partialErr.Add(readOnlyResource, errors.New("register read timed out"))
+	mockDriver := &mocks.ProtocolDriver{}
+	mockDriver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{commandValue}, partialErr)
+
+	tests := []struct {
+		name               string
+		partialReads       bool
+		returnEvent        string
+		expectedStatusCode int
+	}{
+		{"partial reads enabled", true, common.ValueTrue, http.StatusMultiStatus},
+		{"partial reads enabled without event", true, common.ValueFalse, http.StatusMultiStatus},
+		{"partial reads disabled", false, common.ValueTrue, http.StatusInternalServerError},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dic := mockDic()
+			dic.Update(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) any {
+					return &config.ConfigurationStruct{
+						Device: config.DeviceInfo{MaxCmdOps: 1, PartialReads: testCase.partialReads},
+					}
+				},
+				container.ProtocolDriverName: func(get di.Get) any {
+					return mockDriver
+				},
+			})
+			edgexErr := cache.InitCache(testService, dic)
+			require.NoError(t, edgexErr)
+			controller := NewRestController(mux.NewRouter(), dic, testService)
+
+			req, err := http.NewRequest(http.MethodGet, common.ApiDeviceNameCommandNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{common.Name: testDevice, common.Command: testResource})
+			query := req.URL.Query()
+			query.Add(common.ReturnEvent, testCase.returnEvent)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.GetCommand)
+			handler.ServeHTTP(recorder, req)
+
+			var res application.PartialEventResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode != http.StatusMultiStatus {
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+				return
+			}
+			require.Len(t, res.Errors, 1)
+			assert.Equal(t, readOnlyResource, res.Errors[0].DeviceResourceName)
+			assert.Equal(t, http.StatusInternalServerError, res.Errors[0].StatusCode)
+			assert.Contains(t, res.Errors[0].Message, "register read timed out")
+			if testCase.returnEvent == common.ValueTrue {
+				require.Len(t, res.Event.Readings, 1)
+				assert.Equal(t, testResource, res.Event.Readings[0].ResourceName)
+			} else {
+				assert.Empty(t, res.Event.Readings)
+			}
+		})
+	}
+}
+
 func TestRestController_SetCommand(t *testing.T) {
 	validRequest := map[string]any{testResource: "value", writeOnlyResource: "value"}
 	invalidRequest := map[string]any{"invalid": "test"}
@@ -361,6 +459,7 @@ func TestRestController_SetCommand(t *testing.T) {
 		{"invalid - device command resource operations exceed MaxCmdOps", testDevice, exceedCommand, validRequest, http.StatusInternalServerError},
 		{"invalid - write empty string to non string device resource", testDevice, objectResource, emptyValueRequest, http.StatusBadRequest},
 		{"invalid - error in ProtocolDriver implementation", driverErrorDevice, testResource, validRequest, http.StatusInternalServerError},
+		{"invalid - ProtocolDriver call timed out", timeoutDevice, testResource, validRequest, http.StatusServiceUnavailable},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -415,6 +514,281 @@ func TestRestController_SetCommand(t *testing.T) {
 	}
 }
 
+func TestRestController_SetCommand_Verify(t *testing.T) {
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) any {
+			return &config.ConfigurationStruct{
+				Device: config.DeviceInfo{
+					MaxCmdOps:         1,
+					WriteVerification: config.WriteVerificationInfo{Timeout: "50ms", Interval: "10ms"},
+				},
+			}
+		},
+	})
+	messagingClientMock := &messagingMocks.MessageClient{}
+	messagingClientMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.MessagingClientName: func(get di.Get) any {
+			return messagingClientMock
+		},
+	})
+	edgexErr := cache.InitCache(testService, dic)
+	require.NoError(t, edgexErr)
+	controller := NewRestController(mux.NewRouter(), dic, testService)
+
+	tests := []struct {
+		name               string
+		commandName        string
+		value              string
+		verify             string
+		expectedStatusCode int
+	}{
+		// the ProtocolDriver reads back "test" from the test resource
+		{"valid - written value read back", testResource, "test", common.ValueTrue, http.StatusOK},
+		{"valid - written value of device command read back", testCommand, "test", common.ValueTrue, http.StatusOK},
+		{"valid - written value not verified", testResource, "value", common.ValueFalse, http.StatusOK},
+		{"valid - write-only device resource not verified", writeOnlyResource, "value", common.ValueTrue, http.StatusOK},
+		{"invalid - other value read back", testResource, "value", common.ValueTrue, http.StatusInternalServerError},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(map[string]any{testResource: testCase.value, writeOnlyResource: testCase.value})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPut, common.ApiDeviceNameCommandNameRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{common.Name: testDevice, common.Command: testCase.commandName})
+			query := req.URL.Query()
+			query.Add(sdkCommon.Verify, testCase.verify)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.SetCommand)
+			handler.ServeHTTP(recorder, req)
+
+			var res commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				assert.Contains(t, res.Message, "read back test instead of the written value")
+			}
+		})
+	}
+}
+
+func TestRestController_SetCommand_Transactional(t *testing.T) {
+	current := &sdkModels.CommandValue{DeviceResourceName: testResource, Type: common.ValueTypeString, Value: "current"}
+	tests := []struct {
+		name               string
+		commandName        string
+		writeErr           error
+		verify             string
+		expectedStatusCode int
+		expectedMessage    string
+		expectedWrites     int
+	}{
+		{"valid - transactional write", testResource, nil, common.ValueFalse, http.StatusOK, "", 1},
+		{"invalid - driver error rolled back", testResource, errors.New("write failed"), common.ValueFalse, http.StatusInternalServerError, "rolled back DeviceResource(s) test-resource", 2},
+		{"invalid - verification failure rolled back", testCommand, nil, common.ValueTrue, http.StatusInternalServerError, "rolled back DeviceResource(s) test-resource", 2},
+		{"invalid - write-only device resource", writeOnlyResource, nil, common.ValueFalse, http.StatusMethodNotAllowed, "cannot be written transactionally", 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockDriver := &mocks.ProtocolDriver{}
+			mockDriver.On("HandleReadCommands", testDevice, mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{current}, nil)
+			// the first write fails if expected, the rollback write succeeds
+			mockDriver.On("HandleWriteCommands", testDevice, mock.Anything, mock.Anything, mock.Anything).Return(testCase.writeErr).Once()
+			mockDriver.On("HandleWriteCommands", testDevice, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			messagingClientMock := &messagingMocks.MessageClient{}
+			messagingClientMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+			dic := mockDic()
+			dic.Update(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) any {
+					return &config.ConfigurationStruct{
+						Device: config.DeviceInfo{
+							MaxCmdOps:         1,
+							WriteVerification: config.WriteVerificationInfo{Timeout: "30ms", Interval: "10ms"},
+						},
+					}
+				},
+				container.ProtocolDriverName: func(get di.Get) any {
+					return mockDriver
+				},
+				bootstrapContainer.MessagingClientName: func(get di.Get) any {
+					return messagingClientMock
+				},
+			})
+			edgexErr := cache.InitCache(testService, dic)
+			require.NoError(t, edgexErr)
+			controller := NewRestController(mux.NewRouter(), dic, testService)
+
+			jsonData, err := json.Marshal(map[string]any{testResource: "value", writeOnlyResource: "value"})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPut, common.ApiDeviceNameCommandNameRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{common.Name: testDevice, common.Command: testCase.commandName})
+			query := req.URL.Query()
+			query.Add(sdkCommon.Transactional, common.ValueTrue)
+			query.Add(sdkCommon.Verify, testCase.verify)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.SetCommand)
+			handler.ServeHTTP(recorder, req)
+
+			var res commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Contains(t, res.Message, testCase.expectedMessage)
+			mockDriver.AssertNumberOfCalls(t, "HandleWriteCommands", testCase.expectedWrites)
+			if testCase.expectedWrites == 2 {
+				// the snapshot is written back
+				rollback := mockDriver.Calls[len(mockDriver.Calls)-1]
+				params := rollback.Arguments.Get(3).([]*sdkModels.CommandValue)
+				require.Len(t, params, 1)
+				assert.Equal(t, "current", params[0].Value)
+			}
+		})
+	}
+}
+
+func TestRestController_SetCommand_DryRun(t *testing.T) {
+	dic := mockDic()
+	edgexErr := cache.InitCache(testService, dic)
+	require.NoError(t, edgexErr)
+	controller := NewRestController(mux.NewRouter(), dic, testService)
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		commandName        string
+		body               map[string]any
+		expectedStatusCode int
+		expectedValue      any
+	}{
+		{"valid - device resource", driverErrorDevice, testResource, map[string]any{testResource: "value"}, http.StatusOK, "value"},
+		{"valid - device command default value", driverErrorDevice, testCommand, map[string]any{}, http.StatusOK, "default"},
+		{"invalid - read-only device resource", driverErrorDevice, readOnlyResource, map[string]any{readOnlyResource: "value"}, http.StatusMethodNotAllowed, nil},
+		{"invalid - device locked", lockedDevice, testResource, map[string]any{testResource: "value"}, http.StatusLocked, nil},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.body)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPut, common.ApiDeviceNameCommandNameRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{common.Name: testCase.deviceName, common.Command: testCase.commandName})
+			query := req.URL.Query()
+			query.Add(sdkCommon.DryRun, common.ValueTrue)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.SetCommand)
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res application.DryRunResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res.Values, 1)
+			assert.Equal(t, testResource, res.Values[0].DeviceResourceName)
+			assert.Equal(t, common.ValueTypeString, res.Values[0].ValueType)
+			assert.Equal(t, testCase.expectedValue, res.Values[0].Value)
+		})
+	}
+
+	// the ProtocolDriver of driverErrorDevice fails every write, which a dry run does not call
+	mockDriver := container.ProtocolDriverFrom(dic.Get).(*mocks.ProtocolDriver)
+	mockDriver.AssertNotCalled(t, "HandleWriteCommands", driverErrorDevice, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestController_SetCommand_Arming(t *testing.T) {
+	mockDriver := &mocks.ProtocolDriver{}
+	mockDriver.On("HandleWriteCommands", testDevice, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) any {
+			return &config.ConfigurationStruct{
+				Device: config.DeviceInfo{
+					MaxCmdOps: 1,
+					Arming:    config.ArmingInfo{Timeout: "200ms"},
+				},
+			}
+		},
+		container.ProtocolDriverName: func(get di.Get) any {
+			return mockDriver
+		},
+	})
+	edgexErr := cache.InitCache(testService, dic)
+	require.NoError(t, edgexErr)
+	controller := NewRestController(mux.NewRouter(), dic, testService)
+
+	set := func(correlationId string, value string, token string) *httptest.ResponseRecorder {
+		jsonData, err := json.Marshal(map[string]any{armedResource: value})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, common.ApiDeviceNameCommandNameRoute, strings.NewReader(string(jsonData)))
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{common.Name: testDevice, common.Command: armedResource})
+		req = req.WithContext(context.WithValue(req.Context(), common.CorrelationHeader, correlationId)) // nolint: staticcheck
+		if token != "" {
+			query := req.URL.Query()
+			query.Add(sdkCommon.ArmToken, token)
+			req.URL.RawQuery = query.Encode()
+		}
+		recorder := httptest.NewRecorder()
+		handler := http.HandlerFunc(controller.SetCommand)
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+	arm := func(correlationId string, value string) string {
+		recorder := set(correlationId, value, "")
+		require.Equal(t, http.StatusAccepted, recorder.Result().StatusCode, recorder.Body.String())
+		var res application.ArmingResponse
+		err := json.Unmarshal(recorder.Body.Bytes(), &res)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.Token)
+		assert.Greater(t, res.Expiry, time.Now().UnixNano())
+		return res.Token
+	}
+
+	// the SET command arming the command does not write it
+	token := arm("arming-1", "open")
+	mockDriver.AssertNotCalled(t, "HandleWriteCommands", testDevice, mock.Anything, mock.Anything, mock.Anything)
+
+	// the command is armed for the correlation ID which armed it
+	recorder := set("arming-2", "open", "")
+	assert.Equal(t, http.StatusConflict, recorder.Result().StatusCode)
+	recorder = set("arming-2", "open", token)
+	assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	recorder = set("arming-1", "close", token)
+	assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	mockDriver.AssertNotCalled(t, "HandleWriteCommands", testDevice, mock.Anything, mock.Anything, mock.Anything)
+
+	recorder = set("arming-1", "open", token)
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, recorder.Body.String())
+	mockDriver.AssertNumberOfCalls(t, "HandleWriteCommands", 1)
+
+	// the token is consumed by the operation
+	recorder = set("arming-1", "open", token)
+	assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+
+	// the token expires after the Arming Timeout
+	token = arm("arming-3", "open")
+	time.Sleep(250 * time.Millisecond)
+	recorder = set("arming-3", "open", token)
+	assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	assert.Contains(t, recorder.Body.String(), "unknown or expired")
+	mockDriver.AssertNumberOfCalls(t, "HandleWriteCommands", 1)
+}
+
 func TestRestController_SetCommand_ServiceLocked(t *testing.T) {
 	dic := mockDic()
 	dic.Update(di.ServiceConstructorMap{
@@ -456,3 +830,72 @@ func TestRestController_SetCommand_ServiceLocked(t *testing.T) {
 	assert.Equal(t, http.StatusLocked, res.StatusCode, "Response status code not as expected")
 	assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
 }
+
+func TestRestController_GetBatchCommand(t *testing.T) {
+	dic := mockDic()
+
+	err := cache.InitCache(testService, dic)
+	require.NoError(t, err)
+
+	controller := NewRestController(mux.NewRouter(), dic, testService)
+	assert.NotNil(t, controller)
+
+	validRequest := application.BatchCommandRequest{
+		BaseRequest: commonDTO.NewBaseRequest(),
+		Commands: []application.BatchCommand{
+			{DeviceName: testDevice, CommandName: testResource},
+			{DeviceName: testDevice, CommandName: testCommand},
+			{DeviceName: "notFound", CommandName: testCommand},
+			{DeviceName: driverErrorDevice, CommandName: testResource},
+		},
+	}
+	emptyRequest := application.BatchCommandRequest{BaseRequest: commonDTO.NewBaseRequest()}
+	noDeviceNameRequest := application.BatchCommandRequest{
+		BaseRequest: commonDTO.NewBaseRequest(),
+		Commands:    []application.BatchCommand{{CommandName: testCommand}},
+	}
+
+	tests := []struct {
+		name                string
+		request             application.BatchCommandRequest
+		expectedStatusCode  int
+		expectedStatusCodes []int
+	}{
+		{"valid", validRequest, http.StatusMultiStatus, []int{http.StatusOK, http.StatusOK, http.StatusNotFound, http.StatusInternalServerError}},
+		{"invalid - no commands", emptyRequest, http.StatusBadRequest, nil},
+		{"invalid - device name is empty", noDeviceNameRequest, http.StatusBadRequest, nil},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, sdkCommon.ApiBatchCommandRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.GetBatchCommand)
+			handler.ServeHTTP(recorder, req)
+
+			var res application.BatchCommandResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, common.ApiVersion, res.ApiVersion, "API Version not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			require.Equal(t, len(testCase.expectedStatusCodes), len(res.Results), "Result count not as expected")
+			for i, result := range res.Results {
+				assert.Equal(t, testCase.request.Commands[i].DeviceName, result.DeviceName)
+				assert.Equal(t, testCase.expectedStatusCodes[i], result.StatusCode, "Result status code not as expected")
+				if result.StatusCode == http.StatusOK {
+					assert.NotNil(t, result.Event, "Event should be returned when it is successful")
+				} else {
+					assert.NotEmpty(t, result.Message, "Result message doesn't contain the error message")
+				}
+			}
+		})
+	}
+}
@@ -21,10 +21,10 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
-	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	"github.com/gorilla/mux"
 
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/controller/http/correlation"
 )
 
@@ -73,6 +73,13 @@ func (c *RestController) InitRestRoutes() {
 	// device command
 	c.addReservedRoute(common.ApiDeviceNameCommandNameRoute, authenticationHook(c.GetCommand)).Methods(http.MethodGet)
 	c.addReservedRoute(common.ApiDeviceNameCommandNameRoute, authenticationHook(c.SetCommand)).Methods(http.MethodPut)
+	c.addReservedRoute(sdkCommon.ApiBatchCommandRoute, authenticationHook(c.GetBatchCommand)).Methods(http.MethodPost)
+	// autoevent
+	c.addReservedRoute(sdkCommon.ApiAutoEventRoute, authenticationHook(c.AllAutoEventStatuses)).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.ApiAutoEventDeviceNameRoute, authenticationHook(c.AutoEventStatusesByDeviceName)).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.ApiAutoEventPauseRoute, authenticationHook(c.PauseAutoEvent)).Methods(http.MethodPost)
+	c.addReservedRoute(sdkCommon.ApiAutoEventResumeRoute, authenticationHook(c.ResumeAutoEvent)).Methods(http.MethodPost)
+	c.addReservedRoute(sdkCommon.ApiAutoEventTriggerRoute, authenticationHook(c.TriggerAutoEvent)).Methods(http.MethodPost)
 
 	c.router.Use(correlation.ManageHeader)
 	c.router.Use(correlation.LoggingMiddleware(c.lc))
@@ -130,11 +137,16 @@ func (c *RestController) sendResponse(
 	}
 }
 
+// eventResponse is an EventResponse, or an extension of it, which chooses its own encoding.
+type eventResponse interface {
+	Encode() ([]byte, string, error)
+}
+
 // sendEventResponse puts together the EventResponse packet for the V2 API
 func (c *RestController) sendEventResponse(
 	writer http.ResponseWriter,
 	request *http.Request,
-	response responses.EventResponse,
+	response eventResponse,
 	statusCode int) {
 
 	correlationID := request.Header.Get(common.CorrelationHeader)
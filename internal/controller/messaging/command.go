@@ -12,10 +12,12 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
@@ -33,6 +35,9 @@ func SubscribeCommands(ctx context.Context, dic *di.Container) errors.EdgeX {
 	requestSubscribeTopic := common.BuildTopic(messageBusInfo.GetBaseTopicPrefix(), common.CommandRequestSubscribeTopic, deviceService.Name, "#")
 	lc.Infof("Subscribing to command requests on topic: %s", requestSubscribeTopic)
 
+	batchRequestTopic := common.BuildTopic(messageBusInfo.GetBaseTopicPrefix(), common.CommandRequestSubscribeTopic, deviceService.Name, sdkCommon.BatchCommandTopic)
+	lc.Infof("Batch command requests are accepted on topic: %s", batchRequestTopic)
+
 	responsePublishTopicPrefix := common.BuildTopic(messageBusInfo.GetBaseTopicPrefix(), common.ResponseTopic, deviceService.Name)
 	lc.Infof("Responses to command requests will be published on topic: %s/<requestId>", responsePublishTopicPrefix)
 
@@ -62,6 +67,14 @@ func SubscribeCommands(ctx context.Context, dic *di.Container) errors.EdgeX {
 			case msgEnvelope := <-messages:
 				lc.Debugf("Command request received on message queue. Topic: %s, Correlation-id: %s", msgEnvelope.ReceivedTopic, msgEnvelope.CorrelationID)
 
+				// expected batch command request topic scheme: #/<service-name>/batch
+				if msgEnvelope.ReceivedTopic == batchRequestTopic {
+					responsePublishTopic := common.BuildTopic(responsePublishTopicPrefix, msgEnvelope.RequestID)
+					getBatchCommand(ctx, msgEnvelope, responsePublishTopic, dic)
+					lc.Debugf("Batch command response published on message queue. Topic: %s, Correlation-id: %s", responsePublishTopic, msgEnvelope.CorrelationID)
+					continue
+				}
+
 				// expected command request topic scheme: #/<service-name>/<device-name>/<command-name>/<method>
 				topicLevels := strings.Split(msgEnvelope.ReceivedTopic, "/")
 				length := len(topicLevels)
@@ -101,6 +114,8 @@ func SubscribeCommands(ctx context.Context, dic *di.Container) errors.EdgeX {
 
 func getCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, responseTopic string, deviceName string, commandName string, dic *di.Container) {
 	var responseEnvelope types.MessageEnvelope
+	var event *dtos.Event
+	var resourceErrors []application.ResourceError
 
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	messageBus := bootstrapContainer.MessagingClientFrom(dic.Get)
@@ -108,7 +123,10 @@ func getCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 
 	// TODO: fix properly in EdgeX 3.0
 	ctx = context.WithValue(ctx, common.CorrelationHeader, msgEnvelope.CorrelationID) // nolint: staticcheck
-	event, edgexErr := application.GetCommand(ctx, deviceName, commandName, rawQuery, reserved[common.RegexCommand], dic)
+	maxAge, edgexErr := sdkCommon.ParseMaxAge(msgEnvelope.QueryParams[sdkCommon.MaxAge])
+	if edgexErr == nil {
+		event, resourceErrors, edgexErr = application.GetCommand(ctx, deviceName, commandName, rawQuery, reserved[common.RegexCommand], maxAge, msgEnvelope.QueryParams[sdkCommon.Units], dic)
+	}
 	if edgexErr != nil {
 		lc.Errorf("Failed to process get device command %s for device %s: %s", commandName, deviceName, edgexErr.Error())
 		responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, edgexErr.Error())
@@ -122,9 +140,19 @@ func getCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 	var err error
 	var encoding string
 	var eventResponseBytes []byte
-	if reserved[common.ReturnEvent] {
-		eventResponse := responses.NewEventResponse(msgEnvelope.RequestID, "", http.StatusOK, *event)
-		eventResponseBytes, encoding, err = eventResponse.Encode()
+	if reserved[common.ReturnEvent] || len(resourceErrors) > 0 {
+		// the resources which failed to be read by a partial read are listed along with the event
+		if !reserved[common.ReturnEvent] {
+			errorsResponse := application.NewResourceErrorsResponse(msgEnvelope.RequestID, http.StatusMultiStatus, resourceErrors)
+			eventResponseBytes, err = json.Marshal(errorsResponse)
+			encoding = common.ContentTypeJSON
+		} else if len(resourceErrors) > 0 {
+			eventResponse := application.NewPartialEventResponse(msgEnvelope.RequestID, http.StatusMultiStatus, *event, resourceErrors)
+			eventResponseBytes, encoding, err = eventResponse.Encode()
+		} else {
+			eventResponse := responses.NewEventResponse(msgEnvelope.RequestID, "", http.StatusOK, *event)
+			eventResponseBytes, encoding, err = eventResponse.Encode()
+		}
 		if err != nil {
 			lc.Errorf("Failed to encode event response: %s", err.Error())
 			responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, err.Error())
@@ -162,6 +190,77 @@ func getCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 
 }
 
+func getBatchCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, responseTopic string, dic *di.Container) {
+	var responseEnvelope types.MessageEnvelope
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	messageBus := bootstrapContainer.MessagingClientFrom(dic.Get)
+	rawQuery, reserved := filterQueryParams(msgEnvelope.QueryParams)
+
+	var batchRequest application.BatchCommandRequest
+	var maxAge time.Duration
+	err := json.Unmarshal(msgEnvelope.Payload, &batchRequest)
+	if err == nil {
+		err = batchRequest.Validate()
+	}
+	if err == nil {
+		maxAge, err = sdkCommon.ParseMaxAge(msgEnvelope.QueryParams[sdkCommon.MaxAge])
+	}
+	if err != nil {
+		lc.Errorf("Failed to decode batch command request payload: %s", err.Error())
+		responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, err.Error())
+		err = messageBus.Publish(responseEnvelope, responseTopic)
+		if err != nil {
+			lc.Errorf("Failed to publish command error response: %s", err.Error())
+		}
+		return
+	}
+
+	// TODO: fix properly in EdgeX 3.0
+	ctx = context.WithValue(ctx, common.CorrelationHeader, msgEnvelope.CorrelationID) // nolint: staticcheck
+	results := application.GetCommands(ctx, batchRequest.Commands, rawQuery, reserved[common.RegexCommand], maxAge, msgEnvelope.QueryParams[sdkCommon.Units], dic)
+
+	for i := range results {
+		if results[i].Event == nil {
+			continue
+		}
+		if reserved[common.PushEvent] {
+			go sdkCommon.SendEvent(results[i].Event, msgEnvelope.CorrelationID, dic)
+		}
+		if !reserved[common.ReturnEvent] {
+			results[i].Event = nil
+		}
+	}
+
+	batchResponse := application.NewBatchCommandResponse(msgEnvelope.RequestID, http.StatusMultiStatus, results)
+	batchResponseBytes, err := json.Marshal(batchResponse)
+	if err != nil {
+		lc.Errorf("Failed to encode batch command response: %s", err.Error())
+		responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, err.Error())
+		err = messageBus.Publish(responseEnvelope, responseTopic)
+		if err != nil {
+			lc.Errorf("Failed to publish command error response: %s", err.Error())
+		}
+		return
+	}
+
+	responseEnvelope, err = types.NewMessageEnvelopeForResponse(batchResponseBytes, msgEnvelope.RequestID, msgEnvelope.CorrelationID, common.ContentTypeJSON)
+	if err != nil {
+		lc.Errorf("Failed to create response message envelope: %s", err.Error())
+		responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, err.Error())
+		err = messageBus.Publish(responseEnvelope, responseTopic)
+		if err != nil {
+			lc.Errorf("Failed to publish command error response: %s", err.Error())
+		}
+		return
+	}
+
+	err = messageBus.Publish(responseEnvelope, responseTopic)
+	if err != nil {
+		lc.Errorf("Failed to publish command response: %s", err.Error())
+	}
+}
+
 func setCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, responseTopic string, deviceName string, commandName string, dic *di.Container) {
 	var responseEnvelope types.MessageEnvelope
 
@@ -183,7 +282,14 @@ func setCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 
 	// TODO: fix properly in EdgeX 3.0
 	ctx = context.WithValue(ctx, common.CorrelationHeader, msgEnvelope.CorrelationID) // nolint: staticcheck
-	event, edgexErr := application.SetCommand(ctx, deviceName, commandName, rawQuery, requestPayload, dic)
+	options := application.SetCommandOptions{
+		Units:         msgEnvelope.QueryParams[sdkCommon.Units],
+		Verify:        msgEnvelope.QueryParams[sdkCommon.Verify] == common.ValueTrue,
+		Transactional: msgEnvelope.QueryParams[sdkCommon.Transactional] == common.ValueTrue,
+		DryRun:        msgEnvelope.QueryParams[sdkCommon.DryRun] == common.ValueTrue,
+		ArmToken:      msgEnvelope.QueryParams[sdkCommon.ArmToken],
+	}
+	result, edgexErr := application.SetCommand(ctx, deviceName, commandName, rawQuery, options, requestPayload, dic)
 	if edgexErr != nil {
 		lc.Errorf("Failed to process set device command %s for device %s: %s", commandName, deviceName, edgexErr.Error())
 		responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, edgexErr.Error())
@@ -194,7 +300,23 @@ func setCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 		return
 	}
 
-	responseEnvelope, err = types.NewMessageEnvelopeForResponse(nil, msgEnvelope.RequestID, msgEnvelope.CorrelationID, common.ContentTypeJSON)
+	var payload []byte
+	if result.Arming != nil {
+		payload, err = json.Marshal(application.NewArmingResponse(msgEnvelope.RequestID, http.StatusAccepted, *result.Arming))
+	} else if options.DryRun {
+		payload, err = json.Marshal(application.NewDryRunResponse(msgEnvelope.RequestID, http.StatusOK, result.DryRunValues))
+	}
+	if err != nil {
+		lc.Errorf("Failed to encode set command response: %s", err.Error())
+		responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, err.Error())
+		err = messageBus.Publish(responseEnvelope, responseTopic)
+		if err != nil {
+			lc.Errorf("Failed to publish command response: %s", err.Error())
+		}
+		return
+	}
+
+	responseEnvelope, err = types.NewMessageEnvelopeForResponse(payload, msgEnvelope.RequestID, msgEnvelope.CorrelationID, common.ContentTypeJSON)
 	if err != nil {
 		lc.Errorf("Failed to create response message envelope: %s", err.Error())
 		responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, err.Error())
@@ -211,8 +333,8 @@ func setCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 		return
 	}
 
-	if event != nil {
-		go sdkCommon.SendEvent(event, msgEnvelope.CorrelationID, dic)
+	if result.Event != nil {
+		go sdkCommon.SendEvent(result.Event, msgEnvelope.CorrelationID, dic)
 	}
 }
 
@@ -0,0 +1,243 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package eventbuffer implements the file-backed queue holding the Events which failed to be published
+// to the MessageBus until they can be replayed.
+package eventbuffer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fileExtension = ".json"
+	tmpExtension  = ".tmp"
+)
+
+// ErrEntryTooLarge is returned by Push for an Entry which is larger than the size limit of the Buffer.
+var ErrEntryTooLarge = errors.New("entry larger than the event buffer size limit")
+
+// Entry is a buffered Event, or batch of Events, with everything required to publish it again.
+type Entry struct {
+	Topic         string            `json:"topic"`
+	CorrelationID string            `json:"correlationId"`
+	ContentType   string            `json:"contentType"`
+	QueryParams   map[string]string `json:"queryParams,omitempty"`
+	Origin        int64             `json:"origin"`
+	Events        int               `json:"events"`
+	Readings      int               `json:"readings"`
+	Payload       []byte            `json:"payload"`
+}
+
+type bufferedFile struct {
+	name   string
+	origin int64
+	events int
+	size   int64
+}
+
+// Buffer is a queue of Entries ordered by their origin. Each Entry is stored in its own file of the
+// buffer directory so that the queue survives restarts of the service.
+type Buffer struct {
+	dir     string
+	maxSize int64
+	maxAge  time.Duration
+	mutex   sync.Mutex
+	files   []bufferedFile
+	size    int64
+	events  int
+	seq     uint64
+}
+
+// NewBuffer creates a Buffer storing its Entries in dir, the Entries left in dir by a previous run are
+// kept and the temporary files of the Entries it failed to write are removed. The oldest Entries are
+// dropped to keep the total size under maxSize bytes and the Entries with an origin older than maxAge
+// are dropped when replaying, a zero value means no limit.
+func NewBuffer(dir string, maxSize int64, maxAge time.Duration) (*Buffer, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create event buffer directory %s: %w", dir, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event buffer directory %s: %w", dir, err)
+	}
+
+	b := &Buffer{dir: dir, maxSize: maxSize, maxAge: maxAge}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), tmpExtension) {
+			_ = os.Remove(filepath.Join(dir, entry.Name()))
+			continue
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileExtension) {
+			continue
+		}
+		// the file names hold the origin, the sequence number and the number of Events of the Entries
+		var origin int64
+		var seq uint64
+		var events int
+		if _, err := fmt.Sscanf(entry.Name(), "%d-%d-%d"+fileExtension, &origin, &seq, &events); err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		b.files = append(b.files, bufferedFile{name: entry.Name(), origin: origin, events: events, size: info.Size()})
+		b.size += info.Size()
+		b.events += events
+		if seq >= b.seq {
+			b.seq = seq + 1
+		}
+	}
+	sort.Slice(b.files, func(i, j int) bool {
+		return b.files[i].name < b.files[j].name
+	})
+	return b, nil
+}
+
+// Len returns the number of buffered Entries.
+func (b *Buffer) Len() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return len(b.files)
+}
+
+// Events returns the number of Events held by the buffered Entries.
+func (b *Buffer) Events() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.events
+}
+
+// Push adds the Entry to the Buffer and returns the number of Events of the oldest Entries dropped to stay
+// under the size limit. An Entry larger than the size limit is not added and ErrEntryTooLarge is returned.
+func (b *Buffer) Push(entry Entry) (int, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode buffered event: %w", err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.maxSize > 0 && int64(len(data)) > b.maxSize {
+		return 0, fmt.Errorf("%w: %d bytes", ErrEntryTooLarge, len(data))
+	}
+
+	name := fmt.Sprintf("%020d-%020d-%d%s", entry.Origin, b.seq, entry.Events, fileExtension)
+	b.seq++
+	if err := writeFile(filepath.Join(b.dir, name), data); err != nil {
+		return 0, err
+	}
+
+	file := bufferedFile{name: name, origin: entry.Origin, events: entry.Events, size: int64(len(data))}
+	index := sort.Search(len(b.files), func(i int) bool {
+		return b.files[i].name > name
+	})
+	b.files = append(b.files, bufferedFile{})
+	copy(b.files[index+1:], b.files[index:])
+	b.files[index] = file
+	b.size += file.size
+	b.events += file.events
+
+	dropped := 0
+	for b.maxSize > 0 && b.size > b.maxSize && len(b.files) > 0 {
+		dropped += b.files[0].events
+		b.remove(0)
+	}
+	return dropped, nil
+}
+
+// Replay publishes the Entries in origin order until publish fails. The published Entries and the
+// Entries older than the age limit are removed from the Buffer. It returns the number of Events of the
+// published and dropped Entries, and the error of publish if any.
+func (b *Buffer) Replay(publish func(entry Entry) error) (int, int, error) {
+	published := 0
+	dropped := 0
+	for {
+		b.mutex.Lock()
+		if len(b.files) == 0 {
+			b.mutex.Unlock()
+			return published, dropped, nil
+		}
+		file := b.files[0]
+		b.mutex.Unlock()
+
+		if b.maxAge > 0 && time.Since(time.Unix(0, file.origin)) > b.maxAge {
+			b.removeFile(file.name)
+			dropped += file.events
+			continue
+		}
+
+		entry, err := readFile(filepath.Join(b.dir, file.name))
+		if err != nil {
+			// a corrupted file can never be published
+			b.removeFile(file.name)
+			dropped += file.events
+			continue
+		}
+
+		if err := publish(entry); err != nil {
+			return published, dropped, err
+		}
+		b.removeFile(file.name)
+		published += file.events
+	}
+}
+
+// removeFile removes the named file if it has not been dropped by Push in the meantime.
+func (b *Buffer) removeFile(name string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for i, file := range b.files {
+		if file.name == name {
+			b.remove(i)
+			return
+		}
+	}
+}
+
+func (b *Buffer) remove(index int) {
+	_ = os.Remove(filepath.Join(b.dir, b.files[index].name))
+	b.size -= b.files[index].size
+	b.events -= b.files[index].events
+	b.files = append(b.files[:index], b.files[index+1:]...)
+}
+
+// writeFile writes through a temporary file so that a crash never leaves a partial Entry behind.
+func writeFile(path string, data []byte) error {
+	tmpPath := path + tmpExtension
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write buffered event: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write buffered event: %w", err)
+	}
+	return nil
+}
+
+func readFile(path string) (Entry, error) {
+	var entry Entry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
@@ -0,0 +1,211 @@
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventbuffer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntry(origin int64) Entry {
+	return Entry{
+		Topic:         "edgex/events/device/test",
+		CorrelationID: "correlation-id",
+		ContentType:   "application/json",
+		Origin:        origin,
+		Events:        1,
+		Readings:      1,
+		Payload:       []byte(`{"event":"test"}`),
+	}
+}
+
+func TestBuffer_Replay(t *testing.T) {
+	buffer, err := NewBuffer(t.TempDir(), 0, 0)
+	require.NoError(t, err)
+
+	now := time.Now().UnixNano()
+	for _, origin := range []int64{now + 2, now, now + 1} {
+		_, err = buffer.Push(testEntry(origin))
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, buffer.Len())
+
+	var origins []int64
+	published, dropped, err := buffer.Replay(func(entry Entry) error {
+		origins = append(origins, entry.Origin)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, []int64{now, now + 1, now + 2}, origins, "Entries not replayed in origin order")
+	assert.Equal(t, 0, buffer.Len())
+}
+
+func TestBuffer_ReplayFailure(t *testing.T) {
+	buffer, err := NewBuffer(t.TempDir(), 0, 0)
+	require.NoError(t, err)
+
+	now := time.Now().UnixNano()
+	_, err = buffer.Push(testEntry(now))
+	require.NoError(t, err)
+	_, err = buffer.Push(testEntry(now + 1))
+	require.NoError(t, err)
+
+	publishErr := errors.New("unavailable")
+	published, _, err := buffer.Replay(func(entry Entry) error {
+		if entry.Origin == now+1 {
+			return publishErr
+		}
+		return nil
+	})
+	assert.Equal(t, publishErr, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, 1, buffer.Len(), "the Entry failing to be published must be kept")
+}
+
+func TestBuffer_MaxSize(t *testing.T) {
+	now := time.Now().UnixNano()
+	buffer, err := NewBuffer(t.TempDir(), 0, 0)
+	require.NoError(t, err)
+	_, err = buffer.Push(testEntry(now))
+	require.NoError(t, err)
+	entrySize := buffer.size
+
+	buffer, err = NewBuffer(t.TempDir(), entrySize*2, 0)
+	require.NoError(t, err)
+	var dropped int
+	for i := int64(0); i < 3; i++ {
+		dropped, err = buffer.Push(testEntry(now + i))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, 2, buffer.Len())
+
+	var origins []int64
+	_, _, err = buffer.Replay(func(entry Entry) error {
+		origins = append(origins, entry.Origin)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{now + 1, now + 2}, origins, "the oldest Entry must be dropped")
+}
+
+func TestBuffer_EntryTooLarge(t *testing.T) {
+	buffer, err := NewBuffer(t.TempDir(), 10, 0)
+	require.NoError(t, err)
+
+	dropped, err := buffer.Push(testEntry(time.Now().UnixNano()))
+	assert.ErrorIs(t, err, ErrEntryTooLarge)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, 0, buffer.Len())
+}
+
+func TestBuffer_Events(t *testing.T) {
+	now := time.Now().UnixNano()
+	batch := testEntry(now)
+	batch.Events = 3
+	buffer, err := NewBuffer(t.TempDir(), 0, 0)
+	require.NoError(t, err)
+	_, err = buffer.Push(batch)
+	require.NoError(t, err)
+	entrySize := buffer.size
+
+	// the dropped and published counts are the ones of the Events of the Entries
+	dir := t.TempDir()
+	buffer, err = NewBuffer(dir, entrySize, 0)
+	require.NoError(t, err)
+	_, err = buffer.Push(batch)
+	require.NoError(t, err)
+	batch.Origin = now + 1
+	dropped, err := buffer.Push(batch)
+	require.NoError(t, err)
+	assert.Equal(t, 3, dropped)
+	assert.Equal(t, 1, buffer.Len())
+	assert.Equal(t, 3, buffer.Events())
+
+	reloaded, err := NewBuffer(dir, entrySize, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, reloaded.Events())
+	published, _, err := reloaded.Replay(func(entry Entry) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+	assert.Equal(t, 0, reloaded.Events())
+}
+
+func TestBuffer_MaxAge(t *testing.T) {
+	buffer, err := NewBuffer(t.TempDir(), 0, time.Minute)
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, err = buffer.Push(testEntry(now.Add(-time.Hour).UnixNano()))
+	require.NoError(t, err)
+	_, err = buffer.Push(testEntry(now.UnixNano()))
+	require.NoError(t, err)
+
+	published, dropped, err := buffer.Replay(func(entry Entry) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, 1, dropped)
+}
+
+func TestNewBuffer_Reload(t *testing.T) {
+	dir := t.TempDir()
+	buffer, err := NewBuffer(dir, 0, 0)
+	require.NoError(t, err)
+
+	now := time.Now().UnixNano()
+	_, err = buffer.Push(testEntry(now + 1))
+	require.NoError(t, err)
+	_, err = buffer.Push(testEntry(now))
+	require.NoError(t, err)
+
+	reloaded, err := NewBuffer(dir, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, reloaded.Len())
+
+	_, err = reloaded.Push(testEntry(now + 2))
+	require.NoError(t, err)
+	var entries []Entry
+	_, _, err = reloaded.Replay(func(entry Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, testEntry(now), entries[0])
+	assert.Equal(t, now+1, entries[1].Origin)
+	assert.Equal(t, now+2, entries[2].Origin)
+}
+
+func TestNewBuffer_RemovesTemporaryFiles(t *testing.T) {
+	dir := t.TempDir()
+	buffer, err := NewBuffer(dir, 0, 0)
+	require.NoError(t, err)
+	_, err = buffer.Push(testEntry(time.Now().UnixNano()))
+	require.NoError(t, err)
+
+	// the temporary file of an Entry whose write was interrupted by a crash
+	tmpPath := filepath.Join(dir, "00000000000000000001-00000000000000000001.json.tmp")
+	require.NoError(t, os.WriteFile(tmpPath, []byte(`{"topic":`), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "other.tmp"), 0750))
+
+	reloaded, err := NewBuffer(dir, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reloaded.Len())
+	assert.NoFileExists(t, tmpPath)
+	assert.DirExists(t, filepath.Join(dir, "other.tmp"))
+}
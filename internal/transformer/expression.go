@@ -0,0 +1,258 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// expression is a compiled arithmetic expression of the variable x, such as (x - 32) * 5 / 9. It supports
+// the + - * / % ^ operators, parentheses, the pi and e constants and the functions of expressionFunctions.
+type expression func(x float64) float64
+
+var expressionFunctions = map[string]struct {
+	args int
+	f    func(args []float64) float64
+}{
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"sqrt":  {1, func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"exp":   {1, func(a []float64) float64 { return math.Exp(a[0]) }},
+	"log":   {1, func(a []float64) float64 { return math.Log(a[0]) }},
+	"log10": {1, func(a []float64) float64 { return math.Log10(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"pow":   {2, func(a []float64) float64 { return math.Pow(a[0], a[1]) }},
+	"min":   {2, func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	"max":   {2, func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+}
+
+// compiledExpressions caches the expressions by their source, as they are evaluated at every read
+var compiledExpressions sync.Map
+
+// compileExpression compiles the expression, or returns it from the cache.
+func compileExpression(source string) (expression, error) {
+	if e, ok := compiledExpressions.Load(source); ok {
+		return e.(expression), nil
+	}
+
+	p := &expressionParser{source: source}
+	p.next()
+	e, err := p.parseSum()
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression '%s': %w", source, err)
+	}
+	if p.token != "" {
+		return nil, fmt.Errorf("invalid expression '%s': unexpected '%s'", source, p.token)
+	}
+	compiledExpressions.Store(source, e)
+	return e, nil
+}
+
+// expressionParser is a recursive descent parser of expressions, token holds the current token and is
+// empty at the end of the source.
+type expressionParser struct {
+	source string
+	pos    int
+	token  string
+}
+
+func (p *expressionParser) next() {
+	for p.pos < len(p.source) && unicode.IsSpace(rune(p.source[p.pos])) {
+		p.pos++
+	}
+	if p.pos >= len(p.source) {
+		p.token = ""
+		return
+	}
+
+	start := p.pos
+	c := p.source[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.source) {
+			c = p.source[p.pos]
+			isExponent := (c == '+' || c == '-') && (p.source[p.pos-1] == 'e' || p.source[p.pos-1] == 'E')
+			if !(c >= '0' && c <= '9' || c == '.' || c == 'e' || c == 'E' || isExponent) {
+				break
+			}
+			p.pos++
+		}
+	case unicode.IsLetter(rune(c)):
+		for p.pos < len(p.source) && (unicode.IsLetter(rune(p.source[p.pos])) || unicode.IsDigit(rune(p.source[p.pos]))) {
+			p.pos++
+		}
+	default:
+		p.pos++
+	}
+	p.token = p.source[start:p.pos]
+}
+
+// parseSum parses the + and - operations
+func (p *expressionParser) parseSum() (expression, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.token == "+" || p.token == "-" {
+		op := p.token
+		p.next()
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		if op == "+" {
+			left = func(x float64) float64 { return l(x) + right(x) }
+		} else {
+			left = func(x float64) float64 { return l(x) - right(x) }
+		}
+	}
+	return left, nil
+}
+
+// parseProduct parses the *, / and % operations
+func (p *expressionParser) parseProduct() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.token == "*" || p.token == "/" || p.token == "%" {
+		op := p.token
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		switch op {
+		case "*":
+			left = func(x float64) float64 { return l(x) * right(x) }
+		case "/":
+			left = func(x float64) float64 { return l(x) / right(x) }
+		default:
+			left = func(x float64) float64 { return math.Mod(l(x), right(x)) }
+		}
+	}
+	return left, nil
+}
+
+// parseUnary parses the unary + and -, which bind less than ^ so that -x^2 is -(x^2)
+func (p *expressionParser) parseUnary() (expression, error) {
+	switch p.token {
+	case "-":
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(x float64) float64 { return -operand(x) }, nil
+	case "+":
+		p.next()
+		return p.parseUnary()
+	}
+	return p.parsePower()
+}
+
+// parsePower parses the right associative ^ operation
+func (p *expressionParser) parsePower() (expression, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "^" {
+		return base, nil
+	}
+	p.next()
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return func(x float64) float64 { return math.Pow(base(x), exponent(x)) }, nil
+}
+
+func (p *expressionParser) parsePrimary() (expression, error) {
+	token := p.token
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end")
+	case token == "(":
+		p.next()
+		e, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.token != ")" {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		p.next()
+		return e, nil
+	case token[0] >= '0' && token[0] <= '9' || token[0] == '.':
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", token)
+		}
+		p.next()
+		return func(float64) float64 { return value }, nil
+	case unicode.IsLetter(rune(token[0])):
+		p.next()
+		return p.parseIdentifier(strings.ToLower(token))
+	}
+	return nil, fmt.Errorf("unexpected '%s'", token)
+}
+
+func (p *expressionParser) parseIdentifier(name string) (expression, error) {
+	switch name {
+	case "x":
+		return func(x float64) float64 { return x }, nil
+	case "pi":
+		return func(float64) float64 { return math.Pi }, nil
+	case "e":
+		return func(float64) float64 { return math.E }, nil
+	}
+
+	function, ok := expressionFunctions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown identifier '%s'", name)
+	}
+	if p.token != "(" {
+		return nil, fmt.Errorf("missing '(' after function %s", name)
+	}
+	p.next()
+	var args []expression
+	for {
+		arg, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.token != "," {
+			break
+		}
+		p.next()
+	}
+	if p.token != ")" {
+		return nil, fmt.Errorf("missing ')' after the arguments of function %s", name)
+	}
+	p.next()
+	if len(args) != function.args {
+		return nil, fmt.Errorf("function %s takes %d arguments", name, function.args)
+	}
+
+	return func(x float64) float64 {
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			values[i] = arg(x)
+		}
+		return function.f(values)
+	}, nil
+}
@@ -0,0 +1,50 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		x          float64
+		expected   float64
+	}{
+		{"(x - 32) * 5 / 9", 212, 100},
+		{"x * 9 / 5 + 32", 100, 212},
+		{"2 + 3 * x", 2, 8},
+		{"-x^2", 3, -9},
+		{"2^3^2", 0, 512},
+		{"x % 4", 10, 2},
+		{"1.5e3 / x", 3, 500},
+		{"abs(x) + sqrt(16)", -1, 5},
+		{"max(x, 10) - min(x, 10)", 4, 6},
+		{"pow(2, x) + log10(100)", 3, 10},
+		{"round(x * 10) / 10", 1.26, 1.3},
+		{"2 * PI", 0, 2 * math.Pi},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := compileExpression(tt.expression)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, e(tt.x), 1e-9)
+		})
+	}
+}
+
+func TestCompileExpression_Invalid(t *testing.T) {
+	for _, expression := range []string{"", "x +", "(x - 1", "x y", "foo(x)", "sqrt x", "min(x)", "1..2", "x # 2"} {
+		_, err := compileExpression(expression)
+		assert.Error(t, err, "expression '%s' must be invalid", expression)
+	}
+}
@@ -0,0 +1,214 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// validateSchema validates the value against a JSON schema. It supports the type, enum, const, properties,
+// required, additionalProperties, items, minItems, maxItems, minLength, maxLength, pattern, minimum,
+// maximum and multipleOf keywords, which cover the validation of the Object values of device resources.
+func validateSchema(value any, schema any, path string) error {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		if b, ok := schema.(bool); ok && !b {
+			return fmt.Errorf("%s is not allowed", path)
+		}
+		return nil
+	}
+
+	if t, ok := s["type"]; ok {
+		if err := validateSchemaType(value, t, path); err != nil {
+			return err
+		}
+	}
+	if enum, ok := s["enum"].([]any); ok && !containsJSON(enum, value) {
+		return fmt.Errorf("%s must be one of %v", path, enum)
+	}
+	if c, ok := s["const"]; ok && !equalJSON(c, value) {
+		return fmt.Errorf("%s must be %v", path, c)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return validateSchemaObject(v, s, path)
+	case []any:
+		return validateSchemaArray(v, s, path)
+	case string:
+		return validateSchemaString(v, s, path)
+	}
+	if n, ok := jsonNumber(value); ok {
+		return validateSchemaNumber(n, s, path)
+	}
+	return nil
+}
+
+func validateSchemaType(value any, schemaType any, path string) error {
+	types, ok := schemaType.([]any)
+	if !ok {
+		types = []any{schemaType}
+	}
+	for _, t := range types {
+		if jsonType(value, fmt.Sprint(t)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must be of type %v", path, schemaType)
+}
+
+func jsonType(value any, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := jsonNumber(value)
+		return ok
+	case "integer":
+		n, ok := jsonNumber(value)
+		return ok && n == math.Trunc(n)
+	}
+	return false
+}
+
+func validateSchemaObject(value map[string]any, schema map[string]any, path string) error {
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if _, ok := value[fmt.Sprint(name)]; !ok {
+				return fmt.Errorf("%s.%v is required", path, name)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	// validate the fields in a stable order so that the same error is reported for the same value
+	sort.Strings(names)
+	for _, name := range names {
+		propertySchema, ok := properties[name]
+		if !ok {
+			propertySchema, ok = schema["additionalProperties"]
+		}
+		if !ok {
+			continue
+		}
+		if err := validateSchema(value[name], propertySchema, path+"."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSchemaArray(value []any, schema map[string]any, path string) error {
+	if minItems, ok := jsonNumber(schema["minItems"]); ok && float64(len(value)) < minItems {
+		return fmt.Errorf("%s must have at least %v items", path, minItems)
+	}
+	if maxItems, ok := jsonNumber(schema["maxItems"]); ok && float64(len(value)) > maxItems {
+		return fmt.Errorf("%s must have at most %v items", path, maxItems)
+	}
+	if items, ok := schema["items"]; ok {
+		for i, item := range value {
+			if err := validateSchema(item, items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateSchemaString(value string, schema map[string]any, path string) error {
+	length := float64(utf8.RuneCountInString(value))
+	if minLength, ok := jsonNumber(schema["minLength"]); ok && length < minLength {
+		return fmt.Errorf("%s must be at least %v characters long", path, minLength)
+	}
+	if maxLength, ok := jsonNumber(schema["maxLength"]); ok && length > maxLength {
+		return fmt.Errorf("%s must be at most %v characters long", path, maxLength)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern '%s' of %s: %w", pattern, path, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("%s must match the pattern '%s'", path, pattern)
+		}
+	}
+	return nil
+}
+
+func validateSchemaNumber(value float64, schema map[string]any, path string) error {
+	if minimum, ok := jsonNumber(schema["minimum"]); ok && value < minimum {
+		return fmt.Errorf("%s must be greater than or equal to %v", path, minimum)
+	}
+	if maximum, ok := jsonNumber(schema["maximum"]); ok && value > maximum {
+		return fmt.Errorf("%s must be less than or equal to %v", path, maximum)
+	}
+	if multipleOf, ok := jsonNumber(schema["multipleOf"]); ok && multipleOf > 0 && !isMultipleOf(value, 0, multipleOf) {
+		return fmt.Errorf("%s must be a multiple of %v", path, multipleOf)
+	}
+	return nil
+}
+
+// jsonNumber returns the value of a number decoded from JSON or YAML, or given as a Go number.
+func jsonNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case nil, bool, string, []any, map[string]any:
+		return 0, false
+	case int:
+		return float64(v), true
+	case fmt.Stringer:
+		// json.Number
+		n, err := strconv.ParseFloat(v.String(), 64)
+		return n, err == nil
+	}
+	n := toFloat64(value)
+	return n, !math.IsNaN(n)
+}
+
+func equalJSON(a any, b any) bool {
+	if x, ok := jsonNumber(a); ok {
+		y, ok := jsonNumber(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func containsJSON(values []any, value any) bool {
+	for _, v := range values {
+		if equalJSON(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMultipleOf checks whether the value is a multiple of step away from base, within the float64 precision.
+func isMultipleOf(value float64, base float64, step float64) bool {
+	q := (value - base) / step
+	return math.Abs(q-math.Round(q)) <= 1e-9*math.Max(1, math.Abs(q))
+}
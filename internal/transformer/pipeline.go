@@ -0,0 +1,392 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces"
+)
+
+// The types of the built-in transforms of the ds-transforms attribute
+const (
+	transformExpression = "expression"
+	transformLookup     = "lookup"
+	transformPiecewise  = "piecewise"
+	transformClamp      = "clamp"
+)
+
+var customTransforms = struct {
+	mutex      sync.RWMutex
+	transforms map[string]interfaces.Transform
+}{transforms: make(map[string]interfaces.Transform)}
+
+// RegisterTransform registers a custom transform, which the device resources apply by listing an entry
+// of the given type in their ds-transforms attribute.
+func RegisterTransform(name string, transform interfaces.Transform) errors.EdgeX {
+	if name == "" || transform == nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "transform name and implementation must be provided", nil)
+	}
+	switch name {
+	case transformExpression, transformLookup, transformPiecewise, transformClamp:
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("transform name %s is reserved for a built-in transform", name), nil)
+	}
+
+	customTransforms.mutex.Lock()
+	defer customTransforms.mutex.Unlock()
+	if _, ok := customTransforms.transforms[name]; ok {
+		return errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("transform %s is already registered", name), nil)
+	}
+	customTransforms.transforms[name] = transform
+	return nil
+}
+
+// transformStep is a step of the transform pipeline of a device resource, write is the inverse of read.
+type transformStep interface {
+	read(value float64) (float64, error)
+	write(value float64) (float64, error)
+}
+
+// resourceTransforms parses the ds-transforms attribute of the device resource, if any, so that its steps
+// can be applied to every value, or every element of an array value, without being parsed again.
+func resourceTransforms(dr models.DeviceResource) ([]transformStep, errors.EdgeX) {
+	spec, ok := dr.Attributes[sdkCommon.Transforms]
+	if !ok {
+		return nil, nil
+	}
+	return parseTransforms(spec)
+}
+
+// applySteps applies the steps to the value in order on reads, or their inverses in the reverse order
+// on writes.
+func applySteps(value any, steps []transformStep, read bool) (any, errors.EdgeX) {
+	v := toFloat64(value)
+	for i := range steps {
+		var stepErr error
+		if read {
+			v, stepErr = steps[i].read(v)
+		} else {
+			v, stepErr = steps[len(steps)-1-i].write(v)
+		}
+		if stepErr != nil {
+			kind := errors.KindServerError
+			if !read {
+				kind = errors.KindContractInvalid
+			}
+			return nil, errors.NewCommonEdgeX(kind, "failed to apply the ds-transforms of the device resource", stepErr)
+		}
+	}
+	return fromFloat64(value, v)
+}
+
+// parseTransforms parses the list of transforms of the ds-transforms attribute, each transform is an
+// object whose type field is a built-in transform type or the name of a registered custom transform.
+func parseTransforms(spec any) ([]transformStep, errors.EdgeX) {
+	entries, ok := spec.([]any)
+	if !ok {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "ds-transforms must be a list of transforms", nil)
+	}
+
+	steps := make([]transformStep, 0, len(entries))
+	for i, entry := range entries {
+		options, ok := entry.(map[string]any)
+		if !ok {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("ds-transforms entry %d must be an object", i), nil)
+		}
+		step, err := parseTransform(options)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid ds-transforms entry %d", i), err)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func parseTransform(options map[string]any) (transformStep, error) {
+	transformType := fmt.Sprint(options["type"])
+	switch transformType {
+	case transformExpression:
+		return parseExpressionTransform(options)
+	case transformLookup:
+		pairs, err := parsePairs(options, "table")
+		if err != nil {
+			return nil, err
+		}
+		return lookupTransform(pairs), nil
+	case transformPiecewise:
+		return parsePiecewiseTransform(options)
+	case transformClamp:
+		return parseClampTransform(options)
+	}
+
+	customTransforms.mutex.RLock()
+	custom, ok := customTransforms.transforms[transformType]
+	customTransforms.mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown transform type '%s'", transformType)
+	}
+	return customTransform{transform: custom, options: options}, nil
+}
+
+// expressionTransform evaluates an expression of the value on reads, and its inverse expression on writes.
+type expressionTransform struct {
+	expression expression
+	inverse    expression
+}
+
+func parseExpressionTransform(options map[string]any) (transformStep, error) {
+	source, ok := options["expression"]
+	if !ok {
+		return nil, fmt.Errorf("expression is missing")
+	}
+	var t expressionTransform
+	var err error
+	if t.expression, err = compileExpression(fmt.Sprint(source)); err != nil {
+		return nil, err
+	}
+	if inverse, ok := options["inverse"]; ok {
+		if t.inverse, err = compileExpression(fmt.Sprint(inverse)); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (t expressionTransform) read(value float64) (float64, error) {
+	return t.expression(value), nil
+}
+
+func (t expressionTransform) write(value float64) (float64, error) {
+	if t.inverse == nil {
+		return 0, fmt.Errorf("the expression has no inverse, it cannot be applied on writes")
+	}
+	return t.inverse(value), nil
+}
+
+// lookupTransform replaces the values by the values they are paired with in the table.
+type lookupTransform [][2]float64
+
+func (t lookupTransform) read(value float64) (float64, error) {
+	for _, pair := range t {
+		if pair[0] == value {
+			return pair[1], nil
+		}
+	}
+	return 0, fmt.Errorf("no lookup table entry for value %v", value)
+}
+
+func (t lookupTransform) write(value float64) (float64, error) {
+	for _, pair := range t {
+		if pair[1] == value {
+			return pair[0], nil
+		}
+	}
+	return 0, fmt.Errorf("no lookup table entry for value %v", value)
+}
+
+// piecewiseTransform interpolates the values linearly between the points of a calibration curve, and
+// extrapolates them from the first or last segment outside the curve. It is invertible only if the
+// curve is strictly monotonic.
+type piecewiseTransform struct {
+	points     [][2]float64
+	invertible bool
+}
+
+func parsePiecewiseTransform(options map[string]any) (transformStep, error) {
+	points, err := parsePairs(options, "points")
+	if err != nil {
+		return nil, err
+	}
+	if len(points) < 2 {
+		return nil, fmt.Errorf("a piecewise transform needs at least 2 points")
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i][0] < points[j][0] })
+
+	increasing, decreasing := true, true
+	for i := 1; i < len(points); i++ {
+		if points[i][0] == points[i-1][0] {
+			return nil, fmt.Errorf("the points of a piecewise transform must have distinct inputs")
+		}
+		increasing = increasing && points[i][1] > points[i-1][1]
+		decreasing = decreasing && points[i][1] < points[i-1][1]
+	}
+	return piecewiseTransform{points: points, invertible: increasing || decreasing}, nil
+}
+
+func (t piecewiseTransform) read(value float64) (float64, error) {
+	return interpolate(t.points, 0, 1, value), nil
+}
+
+func (t piecewiseTransform) write(value float64) (float64, error) {
+	if !t.invertible {
+		return 0, fmt.Errorf("the piecewise curve is not monotonic, it cannot be applied on writes")
+	}
+	return interpolate(t.points, 1, 0, value), nil
+}
+
+// interpolate interpolates the value at the in coordinate of the points to their out coordinate.
+func interpolate(points [][2]float64, in int, out int, value float64) float64 {
+	// the segment containing the value, or the first or last one to extrapolate
+	i := 1
+	for i < len(points)-1 && (value-points[i][in])*(points[len(points)-1][in]-points[0][in]) > 0 {
+		i++
+	}
+	p0, p1 := points[i-1], points[i]
+	return p0[out] + (value-p0[in])*(p1[out]-p0[out])/(p1[in]-p0[in])
+}
+
+// clampTransform limits the values to the range between min and max, on both reads and writes.
+type clampTransform struct {
+	min *float64
+	max *float64
+}
+
+func parseClampTransform(options map[string]any) (transformStep, error) {
+	var t clampTransform
+	for _, bound := range []struct {
+		name  string
+		value **float64
+	}{{"min", &t.min}, {"max", &t.max}} {
+		v, ok := options[bound.name]
+		if !ok {
+			continue
+		}
+		f, err := strconv.ParseFloat(fmt.Sprint(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clamp %s '%v'", bound.name, v)
+		}
+		*bound.value = &f
+	}
+	if t.min == nil && t.max == nil {
+		return nil, fmt.Errorf("a clamp transform needs a min or a max")
+	}
+	if t.min != nil && t.max != nil && *t.min > *t.max {
+		return nil, fmt.Errorf("the clamp min is greater than the max")
+	}
+	return t, nil
+}
+
+func (t clampTransform) read(value float64) (float64, error) {
+	if t.min != nil && value < *t.min {
+		value = *t.min
+	}
+	if t.max != nil && value > *t.max {
+		value = *t.max
+	}
+	return value, nil
+}
+
+func (t clampTransform) write(value float64) (float64, error) {
+	return t.read(value)
+}
+
+// customTransform applies a transform registered by the driver with the options of its entry.
+type customTransform struct {
+	transform interfaces.Transform
+	options   map[string]any
+}
+
+func (t customTransform) read(value float64) (float64, error) {
+	return t.transform.Read(value, t.options)
+}
+
+func (t customTransform) write(value float64) (float64, error) {
+	return t.transform.Write(value, t.options)
+}
+
+// parsePairs parses a list of pairs of numbers, such as [[0, 10], [1, 20]].
+func parsePairs(options map[string]any, name string) ([][2]float64, error) {
+	list, ok := options[name].([]any)
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("%s must be a non-empty list of pairs of numbers", name)
+	}
+	pairs := make([][2]float64, len(list))
+	for i, item := range list {
+		pair, ok := item.([]any)
+		if !ok || len(pair) != 2 {
+			return nil, fmt.Errorf("%s entry %d must be a pair of numbers", name, i)
+		}
+		for j := range pair {
+			f, err := strconv.ParseFloat(fmt.Sprint(pair[j]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s entry %d must be a pair of numbers", name, i)
+			}
+			pairs[i][j] = f
+		}
+	}
+	return pairs, nil
+}
+
+func toFloat64(value any) float64 {
+	switch v := value.(type) {
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return math.NaN()
+}
+
+// fromFloat64 converts the transformed value to the type of the original value, rounding it to the
+// nearest integer for the integer types.
+func fromFloat64(origin any, transformed float64) (any, errors.EdgeX) {
+	switch origin.(type) {
+	case float32, float64:
+	default:
+		transformed = math.Round(transformed)
+	}
+	if !checkTransformedValueInRange(origin, transformed) {
+		errMsg := fmt.Sprintf("transformed value out of its original type (%T) range", origin)
+		return nil, errors.NewCommonEdgeX(errors.KindOverflowError, errMsg, nil)
+	}
+
+	switch origin.(type) {
+	case uint8:
+		return uint8(transformed), nil
+	case uint16:
+		return uint16(transformed), nil
+	case uint32:
+		return uint32(transformed), nil
+	case uint64:
+		return uint64(transformed), nil
+	case int8:
+		return int8(transformed), nil
+	case int16:
+		return int16(transformed), nil
+	case int32:
+		return int32(transformed), nil
+	case int64:
+		return int64(transformed), nil
+	case float32:
+		return float32(transformed), nil
+	}
+	return transformed, nil
+}
@@ -0,0 +1,136 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	contractsModels "github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+type offsetTransform struct{}
+
+func (offsetTransform) Read(value float64, options map[string]any) (float64, error) {
+	offset, ok := options["offset"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("missing offset")
+	}
+	return value + offset, nil
+}
+
+func (t offsetTransform) Write(value float64, options map[string]any) (float64, error) {
+	offset, err := t.Read(0, options)
+	return value - offset, err
+}
+
+func TestTransformPipeline(t *testing.T) {
+	require.NoError(t, RegisterTransform("testOffset", offsetTransform{}))
+
+	fahrenheitToCelsius := map[string]any{"type": "expression", "expression": "(x - 32) * 5 / 9", "inverse": "x * 9 / 5 + 32"}
+	calibration := map[string]any{"type": "piecewise", "points": []any{[]any{0, 0}, []any{100, 200}, []any{200, 300}}}
+	lookup := map[string]any{"type": "lookup", "table": []any{[]any{1, 10}, []any{2, 20}}}
+	clamp := map[string]any{"type": "clamp", "min": 0, "max": 250}
+	custom := map[string]any{"type": "testOffset", "offset": 1.5}
+
+	tests := []struct {
+		name       string
+		transforms []any
+		value      any
+		read       any
+	}{
+		{"expression", []any{fahrenheitToCelsius}, float64(212), float64(100)},
+		{"expression rounded to integer", []any{fahrenheitToCelsius}, int16(70), int16(21)},
+		{"piecewise", []any{calibration}, float32(50), float32(100)},
+		{"piecewise second segment", []any{calibration}, float32(150), float32(250)},
+		{"piecewise extrapolated", []any{calibration}, float32(-10), float32(-20)},
+		{"lookup", []any{lookup}, uint8(2), uint8(20)},
+		{"clamp", []any{clamp}, int32(300), int32(250)},
+		{"custom", []any{custom}, float64(1), float64(2.5)},
+		{"ordered", []any{calibration, clamp}, float64(150), float64(250)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := transformPipeline(tt.value, tt.transforms, true)
+			require.NoError(t, err)
+			assert.Equal(t, tt.read, res)
+
+			if tt.name == "clamp" || tt.name == "ordered" || tt.name == "expression rounded to integer" {
+				return
+			}
+			// the inverse transforms restore the value on writes
+			res, err = transformPipeline(tt.read, tt.transforms, false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, res)
+		})
+	}
+}
+
+// transformPipeline parses the transforms and applies them to the value.
+func transformPipeline(value any, spec any, read bool) (any, errors.EdgeX) {
+	steps, err := parseTransforms(spec)
+	if err != nil {
+		return nil, err
+	}
+	return applySteps(value, steps, read)
+}
+
+func TestTransformPipeline_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		transforms any
+		value      any
+		read       bool
+		kind       errors.ErrKind
+	}{
+		{"not a list", "x * 2", float64(1), true, errors.KindContractInvalid},
+		{"unknown type", []any{map[string]any{"type": "unknown"}}, float64(1), true, errors.KindContractInvalid},
+		{"invalid expression", []any{map[string]any{"type": "expression", "expression": "x +"}}, float64(1), true, errors.KindContractInvalid},
+		{"no inverse expression", []any{map[string]any{"type": "expression", "expression": "x * 2"}}, float64(1), false, errors.KindContractInvalid},
+		{"lookup miss", []any{map[string]any{"type": "lookup", "table": []any{[]any{1, 10}}}}, float64(2), true, errors.KindServerError},
+		{"not monotonic piecewise", []any{map[string]any{"type": "piecewise", "points": []any{[]any{0, 0}, []any{1, 1}, []any{2, 0}}}}, float64(1), false, errors.KindContractInvalid},
+		{"empty clamp", []any{map[string]any{"type": "clamp"}}, float64(1), true, errors.KindContractInvalid},
+		{"overflow", []any{map[string]any{"type": "expression", "expression": "x * 1000"}}, uint8(1), true, errors.KindOverflowError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := transformPipeline(tt.value, tt.transforms, tt.read)
+			require.Error(t, err)
+			assert.Equal(t, tt.kind, errors.Kind(err))
+		})
+	}
+}
+
+func TestRegisterTransform(t *testing.T) {
+	require.NoError(t, RegisterTransform("testRegister", offsetTransform{}))
+	assert.Equal(t, errors.KindDuplicateName, errors.Kind(RegisterTransform("testRegister", offsetTransform{})))
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(RegisterTransform("expression", offsetTransform{})))
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(RegisterTransform("", offsetTransform{})))
+}
+
+func TestTransformReadResult_Transforms(t *testing.T) {
+	scale := 2.0
+	dr := contractsModels.DeviceResource{
+		Properties: contractsModels.ResourceProperties{ValueType: common.ValueTypeFloat64, Scale: &scale},
+		Attributes: map[string]any{"ds-transforms": []any{map[string]any{"type": "expression", "expression": "x + 1", "inverse": "x - 1"}}},
+	}
+
+	// the ResourceProperties are applied before the transforms on reads, and after their inverses on writes
+	cv, err := models.NewCommandValue("r1", common.ValueTypeFloat64, float64(10))
+	require.NoError(t, err)
+	require.NoError(t, TransformReadResult(cv, dr))
+	assert.Equal(t, float64(21), cv.Value)
+
+	require.NoError(t, TransformWriteParameter(cv, dr))
+	assert.Equal(t, float64(10), cv.Value)
+}
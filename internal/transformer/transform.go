@@ -27,22 +27,38 @@ var (
 	originMutex    sync.Mutex
 )
 
-func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sourceName string, dataTransform bool, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+// CommandValuesToEventDTO transforms the CommandValues read from the device to an Event. The numeric values
+// are converted to the requested units of the ds-units query parameter, or to the ones of the ds-units device
+// property, if dataTransform is enabled.
+func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sourceName string, dataTransform bool, units string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+	event, _, err := commandValuesToEventDTO(cvs, deviceName, sourceName, dataTransform, units, false, dic)
+	return event, err
+}
+
+// CommandValuesToPartialEventDTO transforms the CommandValues to an Event like CommandValuesToEventDTO, but
+// the CommandValues which fail to be transformed, or whose DeviceResource is not found, are left out of the
+// Event instead of failing it. Their errors are returned keyed by DeviceResourceName.
+func CommandValuesToPartialEventDTO(cvs []*models.CommandValue, deviceName string, sourceName string, dataTransform bool, units string, dic *di.Container) (*dtos.Event, map[string]errors.EdgeX, errors.EdgeX) {
+	return commandValuesToEventDTO(cvs, deviceName, sourceName, dataTransform, units, true, dic)
+}
+
+func commandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sourceName string, dataTransform bool, units string, partial bool, dic *di.Container) (*dtos.Event, map[string]errors.EdgeX, errors.EdgeX) {
 	// in some case device service driver implementation would generate no readings
 	// in this case no event would be created. Based on the implementation there would be 2 scenarios:
 	// 1. uninitialized *CommandValue slices, i.e. nil
 	// 2. initialized *CommandValue slice with no value in it.
 	if cvs == nil {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	device, exist := cache.Devices().ForName(deviceName)
 	if !exist {
 		errMsg := fmt.Sprintf("failed to find device %s", deviceName)
-		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
 	}
 
 	var transformsOK = true
+	failures := make(map[string]errors.EdgeX)
 	origin := getUniqueOrigin()
 	tags := make(map[string]interface{})
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
@@ -56,12 +72,16 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 		if !ok {
 			msg := fmt.Sprintf("failed to find DeviceResource %s in Device %s for CommandValue (%s)", cv.DeviceResourceName, deviceName, cv.String())
 			lc.Error(msg)
-			return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, msg, nil)
+			if partial {
+				failures[cv.DeviceResourceName] = errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, msg, nil)
+				continue
+			}
+			return nil, nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, msg, nil)
 		}
 
 		// perform data transformation
 		if dataTransform {
-			edgexErr := TransformReadResult(cv, dr.Properties)
+			edgexErr := TransformReadResult(cv, dr)
 			if edgexErr != nil {
 				lc.Errorf("failed to transform CommandValue (%s): %v", cv.String(), edgexErr)
 
@@ -69,24 +89,37 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 				if errors.Kind(edgexErr) == errors.KindOverflowError {
 					cv, err = models.NewCommandValue(cv.DeviceResourceName, common.ValueTypeString, Overflow)
 					if err != nil {
-						return nil, errors.NewCommonEdgeXWrapper(err)
+						return nil, nil, errors.NewCommonEdgeXWrapper(err)
 					}
+					cv.Quality = models.QualityBad
 				} else if errors.Kind(edgexErr) == errors.KindNaNError {
 					cv, err = models.NewCommandValue(cv.DeviceResourceName, common.ValueTypeString, NaN)
 					if err != nil {
-						return nil, errors.NewCommonEdgeXWrapper(err)
+						return nil, nil, errors.NewCommonEdgeXWrapper(err)
 					}
+					cv.Quality = models.QualityBad
+				} else if partial {
+					failures[cv.DeviceResourceName] = errors.NewCommonEdgeX(errors.KindServerError, "failed to transform value", edgexErr)
+					continue
 				} else {
 					transformsOK = false
 				}
 			}
 		}
 
-		// assertion
+		// assertion, the value failing it is flagged as bad if configured so that the other values can still be published
 		dc := bootstrapContainer.DeviceClientFrom(dic.Get)
 		err := checkAssertion(cv, dr.Properties.Assertion, device.Name, lc, dc)
 		if err != nil {
-			return nil, errors.NewCommonEdgeXWrapper(err)
+			if container.ConfigurationFrom(dic.Get).Writable.Reading.FlagFailedAssertions {
+				lc.Error(err.Error())
+				cv.Quality = models.QualityBad
+			} else if partial {
+				failures[cv.DeviceResourceName] = errors.NewCommonEdgeXWrapper(err)
+				continue
+			} else {
+				return nil, nil, errors.NewCommonEdgeXWrapper(err)
+			}
 		}
 
 		for key, value := range cv.Tags {
@@ -101,20 +134,48 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 		} else if len(ro.Mappings) > 0 {
 			newCV, ok := mapCommandValue(cv, ro.Mappings)
 			if ok {
+				newCV.Quality = cv.Quality
 				cv = newCV
 			}
 		}
 
+		// unit conversion, after the assertion and mappings which are expressed in the Units of the resource
+		targetUnits := ""
+		if dataTransform {
+			targetUnits = TargetUnits(device, dr, units)
+			if edgexErr := convertReadUnits(cv, dr, targetUnits); edgexErr != nil {
+				lc.Errorf("failed to convert CommandValue (%s) to units %s: %v", cv.String(), targetUnits, edgexErr)
+				if partial {
+					failures[cv.DeviceResourceName] = errors.NewCommonEdgeXWrapper(edgexErr)
+				} else {
+					transformsOK = false
+				}
+				continue
+			}
+		}
+
 		reading, err := commandValueToReading(cv, device.Name, device.ProfileName, dr.Properties.MediaType, origin)
 		if err != nil {
-			return nil, errors.NewCommonEdgeXWrapper(err)
+			if partial {
+				failures[cv.DeviceResourceName] = errors.NewCommonEdgeXWrapper(err)
+				continue
+			}
+			return nil, nil, errors.NewCommonEdgeXWrapper(err)
 		}
-		// ReadingUnits=true to include units in the reading
+		// ReadingUnits=true to include units in the reading, the converted values always include their units
 		config := container.ConfigurationFrom(dic.Get)
-		if config.Writable.Reading.ReadingUnits {
+		if targetUnits != "" {
+			reading.Units = targetUnits
+		} else if config.Writable.Reading.ReadingUnits {
 			reading.Units = dr.Properties.Units
 		}
 		sdkCommon.AddReadingTags(&reading)
+		if cv.Quality != "" {
+			if reading.Tags == nil {
+				reading.Tags = make(map[string]any)
+			}
+			reading.Tags[sdkCommon.QualityTag] = cv.Quality
+		}
 		readings = append(readings, reading)
 
 		if cv.Type == common.ValueTypeBinary {
@@ -125,7 +186,7 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 	}
 
 	if !transformsOK {
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to transform value for %s", deviceName), nil)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to transform value for %s", deviceName), nil)
 	}
 
 	if len(readings) > 0 {
@@ -135,10 +196,49 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 		eventDTO.Tags = tags
 		sdkCommon.AddEventTags(&eventDTO)
 
-		return &eventDTO, nil
+		return &eventDTO, failures, nil
 	} else {
+		return nil, failures, nil
+	}
+}
+
+// AggregatedValuesToEventDTO creates the Event of the CommandValues aggregated from the readings of the
+// device. The values are aggregated from readings which are already transformed, so neither the transforms,
+// the Assertion nor the ResourceOperation mappings of their DeviceResource are applied again. The units hold
+// the units of each CommandValue, in the same order, as the values may be converted from the Units of their
+// DeviceResource.
+func AggregatedValuesToEventDTO(cvs []*models.CommandValue, units []string, deviceName string, sourceName string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+	device, exist := cache.Devices().ForName(deviceName)
+	if !exist {
+		errMsg := fmt.Sprintf("failed to find device %s", deviceName)
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	origin := getUniqueOrigin()
+	readings := make([]dtos.BaseReading, 0, len(cvs))
+	for i, cv := range cvs {
+		dr, ok := cache.Profiles().DeviceResource(device.ProfileName, cv.DeviceResourceName)
+		if !ok {
+			errMsg := fmt.Sprintf("failed to find DeviceResource %s in Device %s for CommandValue (%s)", cv.DeviceResourceName, deviceName, cv.String())
+			return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+		}
+		reading, err := commandValueToReading(cv, device.Name, device.ProfileName, dr.Properties.MediaType, origin)
+		if err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+		reading.Units = units[i]
+		sdkCommon.AddReadingTags(&reading)
+		readings = append(readings, reading)
+	}
+	if len(readings) == 0 {
 		return nil, nil
 	}
+
+	eventDTO := dtos.NewEvent(device.ProfileName, device.Name, sourceName)
+	eventDTO.Readings = readings
+	eventDTO.Origin = origin
+	sdkCommon.AddEventTags(&eventDTO)
+	return &eventDTO, nil
 }
 
 func commandValueToReading(cv *models.CommandValue, deviceName, profileName, mediaType string, eventOrigin int64) (dtos.BaseReading, errors.EdgeX) {
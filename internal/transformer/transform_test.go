@@ -8,6 +8,7 @@ package transformer
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"testing"
 
@@ -19,8 +20,10 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
 	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
@@ -168,7 +171,7 @@ func TestCommandValuesToEventDTO_ReadingUnits(t *testing.T) {
 					return configuration
 				},
 			})
-			event, err := CommandValuesToEventDTO(testCase.CommandValues, TestDevice, TestDeviceCommand, configuration.Device.DataTransform, dic)
+			event, err := CommandValuesToEventDTO(testCase.CommandValues, TestDevice, TestDeviceCommand, configuration.Device.DataTransform, "", dic)
 			require.NoError(t, err)
 
 			assert.Equal(t, TestDevice, event.DeviceName)
@@ -184,3 +187,137 @@ func TestCommandValuesToEventDTO_ReadingUnits(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandValuesToEventDTO_Quality(t *testing.T) {
+	dic := NewMockDIC()
+	err := cache.InitCache(TestDeviceService, dic)
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name    string
+		Quality string
+	}{
+		{"no quality", ""},
+		{"good", sdkModels.QualityGood},
+		{"bad", sdkModels.QualityBad},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.Name, func(t *testing.T) {
+			cv, e := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeString, TestValue)
+			require.NoError(t, e)
+			cv.Quality = testCase.Quality
+
+			event, err := CommandValuesToEventDTO([]*sdkModels.CommandValue{cv}, TestDevice, TestDeviceCommand, false, "", dic)
+			require.NoError(t, err)
+			require.Len(t, event.Readings, 1)
+			if testCase.Quality == "" {
+				assert.NotContains(t, event.Readings[0].Tags, "ds-quality")
+			} else {
+				assert.Equal(t, testCase.Quality, event.Readings[0].Tags["ds-quality"])
+			}
+		})
+	}
+}
+
+func TestCommandValuesToPartialEventDTO(t *testing.T) {
+	dic := NewMockDIC()
+	err := cache.InitCache(TestDeviceService, dic)
+	require.NoError(t, err)
+
+	valid, e := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeString, TestValue)
+	require.NoError(t, e)
+	unknown, e := sdkModels.NewCommandValue("unknownResource", common.ValueTypeString, TestValue)
+	require.NoError(t, e)
+	cvs := []*sdkModels.CommandValue{valid, unknown}
+
+	_, err = CommandValuesToEventDTO(cvs, TestDevice, TestDeviceCommand, false, "", dic)
+	require.Error(t, err)
+
+	event, failures, err := CommandValuesToPartialEventDTO(cvs, TestDevice, TestDeviceCommand, false, "", dic)
+	require.NoError(t, err)
+	require.Len(t, event.Readings, 1)
+	assert.Equal(t, TestDeviceResource, event.Readings[0].ResourceName)
+	require.Len(t, failures, 1)
+	assert.Equal(t, errors.KindEntityDoesNotExist, errors.Kind(failures["unknownResource"]))
+}
+
+func TestCommandValuesToEventDTO_SDKQuality(t *testing.T) {
+	scale := 1000.0
+	profile := responses.DeviceProfileResponse{
+		Profile: dtos.DeviceProfile{
+			DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: TestProfile},
+			DeviceResources: []dtos.DeviceResource{
+				{Name: "overflowResource", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt8, ReadWrite: common.ReadWrite_R, Scale: &scale}},
+				{Name: "nanResource", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeFloat32, ReadWrite: common.ReadWrite_R}},
+				{Name: "assertedResource", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeString, ReadWrite: common.ReadWrite_R, Assertion: "OK"}},
+			},
+		},
+	}
+	dpcMock := &clientMocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", context.Background(), TestProfile).Return(profile, nil)
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DevicesByServiceName", context.Background(), TestDeviceService, 0, -1).Return(responses.MultiDevicesResponse{
+		Devices: []dtos.Device{{Name: TestDevice, AdminState: models.Unlocked, OperatingState: models.Up, ServiceName: TestDeviceService, ProfileName: TestProfile}},
+	}, nil)
+	// the failed assertion marks the device DOWN
+	dcMock.On("Update", mock.Anything, mock.Anything).Return(nil, nil)
+
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+	})
+	require.NoError(t, cache.InitCache(TestDeviceService, dic))
+
+	newCommandValue := func(name string, valueType string, value any) *sdkModels.CommandValue {
+		cv, err := sdkModels.NewCommandValue(name, valueType, value)
+		require.NoError(t, err)
+		return cv
+	}
+	tests := []struct {
+		name                 string
+		cv                   *sdkModels.CommandValue
+		flagFailedAssertions bool
+		expectedValue        string
+		expectedQuality      any
+		expectErr            bool
+	}{
+		{"overflow", newCommandValue("overflowResource", common.ValueTypeInt8, int8(100)), false, Overflow, sdkModels.QualityBad, false},
+		{"NaN", newCommandValue("nanResource", common.ValueTypeFloat32, float32(math.NaN())), false, NaN, sdkModels.QualityBad, false},
+		{"assertion passed", newCommandValue("assertedResource", common.ValueTypeString, "OK"), true, "OK", nil, false},
+		{"assertion failed and flagged", newCommandValue("assertedResource", common.ValueTypeString, "FAULT"), true, "FAULT", sdkModels.QualityBad, false},
+		{"assertion failed", newCommandValue("assertedResource", common.ValueTypeString, "FAULT"), false, "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container.ConfigurationFrom(dic.Get).Writable.Reading.FlagFailedAssertions = tt.flagFailedAssertions
+
+			event, err := CommandValuesToEventDTO([]*sdkModels.CommandValue{tt.cv}, TestDevice, TestDeviceCommand, true, "", dic)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, event.Readings, 1)
+			assert.Equal(t, tt.expectedValue, event.Readings[0].Value)
+			assert.Equal(t, tt.expectedQuality, event.Readings[0].Tags["ds-quality"])
+		})
+	}
+
+	// the failed assertion is reported as the failure of the resource on partial reads
+	container.ConfigurationFrom(dic.Get).Writable.Reading.FlagFailedAssertions = false
+	cvs := []*sdkModels.CommandValue{
+		newCommandValue("assertedResource", common.ValueTypeString, "FAULT"),
+		newCommandValue("nanResource", common.ValueTypeFloat32, float32(1)),
+	}
+	event, failures, err := CommandValuesToPartialEventDTO(cvs, TestDevice, TestDeviceCommand, true, "", dic)
+	require.NoError(t, err)
+	require.Len(t, event.Readings, 1)
+	assert.Equal(t, "nanResource", event.Readings[0].ResourceName)
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures["assertedResource"].Error(), "Assertion failed")
+}
@@ -0,0 +1,100 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// numericElement is the element type of the numeric array value types
+type numericElement interface {
+	uint8 | uint16 | uint32 | uint64 | int8 | int16 | int32 | int64 | float32 | float64
+}
+
+func isNumericArrayValueType(cv *sdkModels.CommandValue) bool {
+	switch cv.Type {
+	case common.ValueTypeUint8Array, common.ValueTypeUint16Array, common.ValueTypeUint32Array, common.ValueTypeUint64Array,
+		common.ValueTypeInt8Array, common.ValueTypeInt16Array, common.ValueTypeInt32Array, common.ValueTypeInt64Array,
+		common.ValueTypeFloat32Array, common.ValueTypeFloat64Array:
+		return true
+	default:
+		return false
+	}
+}
+
+// transformArray replaces the numeric array value of the CommandValue by the array of its elements
+// transformed by the function, or leaves it unchanged if an element fails to be transformed.
+func transformArray(cv *sdkModels.CommandValue, transform func(value any) (any, errors.EdgeX)) errors.EdgeX {
+	var result any
+	var err errors.EdgeX
+	switch values := cv.Value.(type) {
+	case []uint8:
+		result, err = transformElements(values, transform)
+	case []uint16:
+		result, err = transformElements(values, transform)
+	case []uint32:
+		result, err = transformElements(values, transform)
+	case []uint64:
+		result, err = transformElements(values, transform)
+	case []int8:
+		result, err = transformElements(values, transform)
+	case []int16:
+		result, err = transformElements(values, transform)
+	case []int32:
+		result, err = transformElements(values, transform)
+	case []int64:
+		result, err = transformElements(values, transform)
+	case []float32:
+		result, err = transformElements(values, transform)
+	case []float64:
+		result, err = transformElements(values, transform)
+	default:
+		errMsg := fmt.Sprintf("unexpected value type %T of the %s CommandValue for transformation", cv.Value, cv.Type)
+		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+	}
+	if err != nil {
+		return err
+	}
+	cv.Value = result
+	return nil
+}
+
+func transformElements[T numericElement](values []T, transform func(value any) (any, errors.EdgeX)) ([]T, errors.EdgeX) {
+	result := make([]T, len(values))
+	for i, v := range values {
+		transformed, err := transform(v)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to transform array element %d", i), err)
+		}
+		result[i] = transformed.(T)
+	}
+	return result, nil
+}
+
+func isNaNValue(value any) bool {
+	switch v := value.(type) {
+	case float32:
+		return math.IsNaN(float64(v))
+	case float64:
+		return math.IsNaN(v)
+	}
+	return false
+}
+
+func isUnsignedInteger(value any) bool {
+	switch value.(type) {
+	case uint8, uint16, uint32, uint64:
+		return true
+	}
+	return false
+}
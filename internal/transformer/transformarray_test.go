@@ -0,0 +1,89 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	contractsModels "github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+func TestTransformReadResult_Array(t *testing.T) {
+	scale := 0.5
+	intScale := 2.0
+	offset := 1.0
+	mask := uint64(0x0F)
+	tests := []struct {
+		name      string
+		valueType string
+		value     any
+		props     contractsModels.ResourceProperties
+		expected  any
+		errorKind errors.ErrKind
+	}{
+		{"Float32Array scale and offset", common.ValueTypeFloat32Array, []float32{2, 4, 6}, contractsModels.ResourceProperties{Scale: &scale, Offset: &offset}, []float32{2, 3, 4}, ""},
+		{"Int16Array scale", common.ValueTypeInt16Array, []int16{-4, 0, 4}, contractsModels.ResourceProperties{Scale: &intScale}, []int16{-8, 0, 8}, ""},
+		{"Uint8Array mask", common.ValueTypeUint8Array, []uint8{0x1F, 0xF2}, contractsModels.ResourceProperties{Mask: &mask}, []uint8{0x0F, 0x02}, ""},
+		{"Float64Array NaN element", common.ValueTypeFloat64Array, []float64{1, math.NaN()}, contractsModels.ResourceProperties{}, nil, errors.KindNaNError},
+		{"Uint8Array overflow", common.ValueTypeUint8Array, []uint8{254, 255}, contractsModels.ResourceProperties{Offset: &offset}, nil, errors.KindOverflowError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := models.NewCommandValue("r1", tt.valueType, tt.value)
+			require.NoError(t, err)
+			edgexErr := TransformReadResult(cv, contractsModels.DeviceResource{Properties: tt.props})
+			if tt.errorKind != "" {
+				require.Error(t, edgexErr)
+				assert.Equal(t, tt.errorKind, errors.Kind(edgexErr))
+				assert.Equal(t, tt.value, cv.Value, "the value must be left unchanged")
+				return
+			}
+			require.NoError(t, edgexErr)
+			assert.Equal(t, tt.expected, cv.Value)
+		})
+	}
+}
+
+func TestTransformWriteParameter_Array(t *testing.T) {
+	scale := 2.0
+	minimum := -10.0
+	maximum := 10.0
+	props := contractsModels.ResourceProperties{Scale: &scale, Minimum: &minimum, Maximum: &maximum}
+	tests := []struct {
+		name      string
+		valueType string
+		value     any
+		expected  any
+		errorKind errors.ErrKind
+	}{
+		{"Float32Array inverse scale", common.ValueTypeFloat32Array, []float32{2, -5}, []float32{1, -2.5}, ""},
+		{"Int32Array inverse scale", common.ValueTypeInt32Array, []int32{-10, 10}, []int32{-5, 5}, ""},
+		{"Int16Array above maximum", common.ValueTypeInt16Array, []int16{2, 11}, nil, errors.KindContractInvalid},
+		{"Float64Array below minimum", common.ValueTypeFloat64Array, []float64{-11}, nil, errors.KindContractInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := models.NewCommandValue("r1", tt.valueType, tt.value)
+			require.NoError(t, err)
+			edgexErr := TransformWriteParameter(cv, contractsModels.DeviceResource{Properties: props})
+			if tt.errorKind != "" {
+				require.Error(t, edgexErr)
+				assert.Equal(t, tt.errorKind, errors.Kind(edgexErr))
+				return
+			}
+			require.NoError(t, edgexErr)
+			assert.Equal(t, tt.expected, cv.Value)
+		})
+	}
+}
@@ -14,52 +14,84 @@ import (
 	dsModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
 )
 
-func TransformWriteParameter(cv *dsModels.CommandValue, pv models.ResourceProperties) errors.EdgeX {
-	if !isNumericValueType(cv) {
+// TransformWriteParameter validates the value written to the device resource, in its Units, against its Maximum
+// and Minimum, and applies the inverses of its ds-transforms attribute and of its offset, scale and base. The
+// numeric arrays are validated and transformed element-wise.
+func TransformWriteParameter(cv *dsModels.CommandValue, dr models.DeviceResource) errors.EdgeX {
+	if !isNumericArrayValueType(cv) && !isNumericValueType(cv) {
 		return nil
 	}
 
+	steps, err := resourceTransforms(dr)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	if isNumericArrayValueType(cv) {
+		return transformArray(cv, func(value any) (any, errors.EdgeX) {
+			return transformWriteValue(value, dr, steps)
+		})
+	}
+
 	value, err := commandValueForTransform(cv)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
+	newValue, err := transformWriteValue(value, dr, steps)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	if value != newValue {
+		cv.Value = newValue
+	}
+	return nil
+}
+
+// transformWriteValue validates a numeric value written to the device resource and applies the inverse
+// write transforms, the steps are the ones parsed from its ds-transforms attribute.
+func transformWriteValue(value any, dr models.DeviceResource, steps []transformStep) (any, errors.EdgeX) {
+	pv := dr.Properties
 	newValue := value
+	var err errors.EdgeX
 
 	if pv.Maximum != nil {
-		err = validateWriteMaximum(value, *pv.Maximum)
+		err = validateWriteMaximum(newValue, *pv.Maximum)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Minimum != nil {
-		err = validateWriteMinimum(value, *pv.Minimum)
+		err = validateWriteMinimum(newValue, *pv.Minimum)
+		if err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	if len(steps) > 0 {
+		newValue, err = applySteps(newValue, steps, false)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Offset != nil && *pv.Offset != defaultOffset {
 		newValue, err = transformOffset(newValue, *pv.Offset, false)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Scale != nil && *pv.Scale != defaultScale {
 		newValue, err = transformScale(newValue, *pv.Scale, false)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Base != nil && *pv.Base != defaultBase {
 		newValue, err = transformBase(newValue, *pv.Base, false)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
-
-	if value != newValue {
-		cv.Value = newValue
-	}
-	return nil
+	return newValue, nil
 }
 
 func validateWriteMaximum(value any, maximum float64) errors.EdgeX {
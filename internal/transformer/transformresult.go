@@ -31,10 +31,27 @@ const (
 	NaN      = "NaN"
 )
 
-func TransformReadResult(cv *sdkModels.CommandValue, pv models.ResourceProperties) errors.EdgeX {
-	if !isNumericValueType(cv) {
+// TransformReadResult applies the mask, shift, base, scale and offset of the ResourceProperties of the
+// device resource to the value read, followed by the transforms of its ds-transforms attribute. The
+// numeric arrays are transformed element-wise.
+func TransformReadResult(cv *sdkModels.CommandValue, dr models.DeviceResource) errors.EdgeX {
+	if !isNumericArrayValueType(cv) && !isNumericValueType(cv) {
 		return nil
 	}
+	steps, err := resourceTransforms(dr)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	if isNumericArrayValueType(cv) {
+		return transformArray(cv, func(value any) (any, errors.EdgeX) {
+			if isNaNValue(value) {
+				errMSg := fmt.Sprintf("NaN error for DeviceResource %s", cv.DeviceResourceName)
+				return nil, errors.NewCommonEdgeX(errors.KindNaNError, errMSg, nil)
+			}
+			return transformReadValue(value, dr, steps)
+		})
+	}
 	res, err := isNaN(cv)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -47,45 +64,61 @@ func TransformReadResult(cv *sdkModels.CommandValue, pv models.ResourcePropertie
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
+	newValue, err := transformReadValue(value, dr, steps)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	if value != newValue {
+		cv.Value = newValue
+	}
+	return nil
+}
+
+// transformReadValue applies the read transforms of the device resource to a numeric value, the steps are
+// the ones parsed from its ds-transforms attribute.
+func transformReadValue(value any, dr models.DeviceResource, steps []transformStep) (any, errors.EdgeX) {
+	pv := dr.Properties
 	newValue := value
+	var err errors.EdgeX
 
-	if pv.Mask != nil && *pv.Mask != defaultMask &&
-		(cv.Type == common.ValueTypeUint8 || cv.Type == common.ValueTypeUint16 || cv.Type == common.ValueTypeUint32 || cv.Type == common.ValueTypeUint64) {
+	if pv.Mask != nil && *pv.Mask != defaultMask && isUnsignedInteger(value) {
 		newValue, err = transformReadMask(newValue, *pv.Mask)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
-	if pv.Shift != nil && *pv.Shift != defaultShift &&
-		(cv.Type == common.ValueTypeUint8 || cv.Type == common.ValueTypeUint16 || cv.Type == common.ValueTypeUint32 || cv.Type == common.ValueTypeUint64) {
+	if pv.Shift != nil && *pv.Shift != defaultShift && isUnsignedInteger(value) {
 		newValue, err = transformReadShift(newValue, *pv.Shift)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Base != nil && *pv.Base != defaultBase {
 		newValue, err = transformBase(newValue, *pv.Base, true)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Scale != nil && *pv.Scale != defaultScale {
 		newValue, err = transformScale(newValue, *pv.Scale, true)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Offset != nil && *pv.Offset != defaultOffset {
 		newValue, err = transformOffset(newValue, *pv.Offset, true)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
-
-	if value != newValue {
-		cv.Value = newValue
+	if len(steps) > 0 {
+		newValue, err = applySteps(newValue, steps, true)
+		if err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
 	}
-	return nil
+	return newValue, nil
 }
 
 func transformBase(value any, base float64, read bool) (any, errors.EdgeX) {
@@ -0,0 +1,343 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// The quantities measured by the units which can be converted to each other
+const (
+	quantityTemperature = "temperature"
+	quantityPressure    = "pressure"
+	quantityFlow        = "flow"
+	quantityEnergy      = "energy"
+	quantityLength      = "length"
+)
+
+// unit converts the values of a quantity to its base unit, base = value * factor + offset.
+type unit struct {
+	quantity string
+	factor   float64
+	offset   float64
+}
+
+// units are the known engineering units, keyed by their symbols and common aliases, the base units are
+// K, Pa, m3/s, J and m.
+var units = map[string]unit{
+	"K":          {quantityTemperature, 1, 0},
+	"kelvin":     {quantityTemperature, 1, 0},
+	"C":          {quantityTemperature, 1, 273.15},
+	"°C":         {quantityTemperature, 1, 273.15},
+	"degC":       {quantityTemperature, 1, 273.15},
+	"celsius":    {quantityTemperature, 1, 273.15},
+	"F":          {quantityTemperature, 5.0 / 9, 459.67 * 5 / 9},
+	"°F":         {quantityTemperature, 5.0 / 9, 459.67 * 5 / 9},
+	"degF":       {quantityTemperature, 5.0 / 9, 459.67 * 5 / 9},
+	"fahrenheit": {quantityTemperature, 5.0 / 9, 459.67 * 5 / 9},
+
+	"Pa":    {quantityPressure, 1, 0},
+	"hPa":   {quantityPressure, 1e2, 0},
+	"kPa":   {quantityPressure, 1e3, 0},
+	"MPa":   {quantityPressure, 1e6, 0},
+	"mbar":  {quantityPressure, 1e2, 0},
+	"bar":   {quantityPressure, 1e5, 0},
+	"psi":   {quantityPressure, 6894.757293168361, 0},
+	"atm":   {quantityPressure, 101325, 0},
+	"mmHg":  {quantityPressure, 133.322387415, 0},
+	"inHg":  {quantityPressure, 3386.389, 0},
+	"inH2O": {quantityPressure, 249.08891, 0},
+
+	"m3/s":  {quantityFlow, 1, 0},
+	"m3/h":  {quantityFlow, 1.0 / 3600, 0},
+	"L/s":   {quantityFlow, 1e-3, 0},
+	"L/min": {quantityFlow, 1e-3 / 60, 0},
+	"L/h":   {quantityFlow, 1e-3 / 3600, 0},
+	"gpm":   {quantityFlow, 3.785411784e-3 / 60, 0},
+	"cfm":   {quantityFlow, 0.028316846592 / 60, 0},
+
+	"J":    {quantityEnergy, 1, 0},
+	"kJ":   {quantityEnergy, 1e3, 0},
+	"MJ":   {quantityEnergy, 1e6, 0},
+	"Wh":   {quantityEnergy, 3600, 0},
+	"kWh":  {quantityEnergy, 3.6e6, 0},
+	"MWh":  {quantityEnergy, 3.6e9, 0},
+	"cal":  {quantityEnergy, 4.184, 0},
+	"kcal": {quantityEnergy, 4184, 0},
+	"BTU":  {quantityEnergy, 1055.05585262, 0},
+
+	"m":  {quantityLength, 1, 0},
+	"km": {quantityLength, 1e3, 0},
+	"cm": {quantityLength, 1e-2, 0},
+	"mm": {quantityLength, 1e-3, 0},
+	"um": {quantityLength, 1e-6, 0},
+	"in": {quantityLength, 0.0254, 0},
+	"ft": {quantityLength, 0.3048, 0},
+	"yd": {quantityLength, 0.9144, 0},
+	"mi": {quantityLength, 1609.344, 0},
+}
+
+// ValidateUnits checks that the units requested by the ds-units query parameter are known.
+func ValidateUnits(requested string) errors.EdgeX {
+	if _, ok := units[requested]; requested != "" && !ok {
+		errMsg := fmt.Sprintf("unknown units '%s' of query parameter %s", requested, sdkCommon.Units)
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+	return nil
+}
+
+// TargetUnits returns the units the values of the device resource are converted to, or an empty string if
+// they are not converted. The requested units of the ds-units query parameter apply to the device resources
+// whose Units measure the same quantity, the other ones are converted to the units mapped to their name, or
+// else to their quantity, by the ds-units property of the device, such as {"pressure": "kPa"}.
+func TargetUnits(device models.Device, dr models.DeviceResource, requested string) string {
+	resourceUnit, ok := units[dr.Properties.Units]
+	if !ok {
+		return ""
+	}
+	target := requested
+	if u, ok := units[target]; !ok || u.quantity != resourceUnit.quantity {
+		target = deviceTargetUnits(device.Properties, dr.Name, resourceUnit.quantity)
+	}
+	if u, ok := units[target]; !ok || u.quantity != resourceUnit.quantity || target == dr.Properties.Units {
+		return ""
+	}
+	return target
+}
+
+// deviceTargetUnits returns the units mapped to the device resource, or else to its quantity, by the
+// ds-units device property.
+func deviceTargetUnits(properties map[string]any, resourceName string, quantity string) string {
+	deviceUnits, _ := properties[sdkCommon.Units].(map[string]any)
+	if u, ok := deviceUnits[resourceName]; ok {
+		return fmt.Sprint(u)
+	}
+	if u, ok := deviceUnits[quantity]; ok {
+		return fmt.Sprint(u)
+	}
+	return ""
+}
+
+// unitConversion converts the values from the Units of a device resource to the target units on reads,
+// and back on writes.
+type unitConversion struct {
+	from unit
+	to   unit
+}
+
+// convertReadUnits converts the numeric value read from the Units of the device resource to the given units.
+// The integer values are converted to Float64 values, so that the converted values are not rounded.
+func convertReadUnits(cv *sdkModels.CommandValue, dr models.DeviceResource, units string) errors.EdgeX {
+	return convertUnits(cv, dr, units, true)
+}
+
+// ConvertWriteUnits converts the numeric value written in the given units, if not empty, to the Units of the
+// device resource, so that it is validated in the Units of the device resource. The values written to an
+// integer device resource are parsed as Float64 values, see WriteValueType, and are rounded to its ValueType
+// once converted.
+func ConvertWriteUnits(cv *sdkModels.CommandValue, dr models.DeviceResource, units string) errors.EdgeX {
+	if err := convertUnits(cv, dr, units, false); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if cv.Type == dr.Properties.ValueType || !isIntegerValueType(dr.Properties.ValueType) {
+		return nil
+	}
+	if err := fromFloat64CommandValue(cv, dr.Properties.ValueType); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to convert set parameter", err)
+	}
+	return nil
+}
+
+// convertUnits converts the numeric value from the Units of the device resource to the given units on reads,
+// or back on writes. The integer values are converted to Float64 values.
+func convertUnits(cv *sdkModels.CommandValue, dr models.DeviceResource, units string, read bool) errors.EdgeX {
+	if !isNumericArrayValueType(cv) && !isNumericValueType(cv) {
+		return nil
+	}
+	conversion, err := unitConversionStep(dr, units)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	} else if len(conversion) == 0 {
+		return nil
+	}
+	if isIntegerValueType(cv.Type) {
+		toFloat64CommandValue(cv)
+	}
+
+	if isNumericArrayValueType(cv) {
+		return transformArray(cv, func(value any) (any, errors.EdgeX) {
+			return applySteps(value, conversion, read)
+		})
+	}
+	value, err := commandValueForTransform(cv)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	newValue, err := applySteps(value, conversion, read)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	cv.Value = newValue
+	return nil
+}
+
+// WriteValueType returns the ValueType the value written to the device resource is parsed as. The values
+// written in other units to an integer device resource are parsed as Float64 values, as they are only
+// rounded to integers once converted to the Units of the device resource by ConvertWriteUnits.
+func WriteValueType(dr models.DeviceResource, units string) string {
+	valueType := dr.Properties.ValueType
+	if units == "" || units == dr.Properties.Units || !isIntegerValueType(valueType) {
+		return valueType
+	}
+	if _, ok := integerArrayElementTypes[valueType]; ok {
+		return common.ValueTypeFloat64Array
+	}
+	return common.ValueTypeFloat64
+}
+
+// integerZeroValues are the zero values of the integer value types
+var integerZeroValues = map[string]any{
+	common.ValueTypeUint8:  uint8(0),
+	common.ValueTypeUint16: uint16(0),
+	common.ValueTypeUint32: uint32(0),
+	common.ValueTypeUint64: uint64(0),
+	common.ValueTypeInt8:   int8(0),
+	common.ValueTypeInt16:  int16(0),
+	common.ValueTypeInt32:  int32(0),
+	common.ValueTypeInt64:  int64(0),
+}
+
+// integerArrayElementTypes are the element value types of the integer array value types
+var integerArrayElementTypes = map[string]string{
+	common.ValueTypeUint8Array:  common.ValueTypeUint8,
+	common.ValueTypeUint16Array: common.ValueTypeUint16,
+	common.ValueTypeUint32Array: common.ValueTypeUint32,
+	common.ValueTypeUint64Array: common.ValueTypeUint64,
+	common.ValueTypeInt8Array:   common.ValueTypeInt8,
+	common.ValueTypeInt16Array:  common.ValueTypeInt16,
+	common.ValueTypeInt32Array:  common.ValueTypeInt32,
+	common.ValueTypeInt64Array:  common.ValueTypeInt64,
+}
+
+func isIntegerValueType(valueType string) bool {
+	_, integer := integerZeroValues[valueType]
+	_, integerArray := integerArrayElementTypes[valueType]
+	return integer || integerArray
+}
+
+// toFloat64CommandValue replaces the integer value of the CommandValue by a Float64 value, or the integer
+// array value by a Float64Array value.
+func toFloat64CommandValue(cv *sdkModels.CommandValue) {
+	if _, ok := integerArrayElementTypes[cv.Type]; ok {
+		var values []float64
+		for _, v := range elements(cv.Value) {
+			values = append(values, toFloat64(v))
+		}
+		cv.Type = common.ValueTypeFloat64Array
+		cv.Value = values
+		return
+	}
+	cv.Type = common.ValueTypeFloat64
+	cv.Value = toFloat64(cv.Value)
+}
+
+// fromFloat64CommandValue replaces the Float64 or Float64Array value of the CommandValue by the value of the
+// given integer value type, rounded to the nearest integers.
+func fromFloat64CommandValue(cv *sdkModels.CommandValue, valueType string) errors.EdgeX {
+	var value any
+	var err errors.EdgeX
+	switch values := cv.Value.(type) {
+	case float64:
+		value, err = fromFloat64(integerZeroValues[valueType], values)
+	case []float64:
+		switch integerArrayElementTypes[valueType] {
+		case common.ValueTypeUint8:
+			value, err = castElements[uint8](values)
+		case common.ValueTypeUint16:
+			value, err = castElements[uint16](values)
+		case common.ValueTypeUint32:
+			value, err = castElements[uint32](values)
+		case common.ValueTypeUint64:
+			value, err = castElements[uint64](values)
+		case common.ValueTypeInt8:
+			value, err = castElements[int8](values)
+		case common.ValueTypeInt16:
+			value, err = castElements[int16](values)
+		case common.ValueTypeInt32:
+			value, err = castElements[int32](values)
+		case common.ValueTypeInt64:
+			value, err = castElements[int64](values)
+		default:
+			err = errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("unexpected integer array value type %s", valueType), nil)
+		}
+	default:
+		err = errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("unexpected value type %T of the %s CommandValue", cv.Value, cv.Type), nil)
+	}
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to convert the value to %s", valueType), err)
+	}
+	cv.Type = valueType
+	cv.Value = value
+	return nil
+}
+
+func castElements[T numericElement](values []float64) ([]T, errors.EdgeX) {
+	var zero T
+	result := make([]T, len(values))
+	for i, v := range values {
+		value, err := fromFloat64(zero, v)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to convert array element %d", i), err)
+		}
+		result[i] = value.(T)
+	}
+	return result, nil
+}
+
+// unitConversionStep returns the conversion from the Units of the device resource to the given units, or
+// no step if the units are empty or the same.
+func unitConversionStep(dr models.DeviceResource, to string) ([]transformStep, errors.EdgeX) {
+	if to == "" || to == dr.Properties.Units {
+		return nil, nil
+	}
+	conversion, err := newUnitConversion(dr.Properties.Units, to)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	return []transformStep{conversion}, nil
+}
+
+func newUnitConversion(from string, to string) (transformStep, errors.EdgeX) {
+	fromUnit, ok := units[from]
+	if !ok {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unknown units '%s'", from), nil)
+	}
+	toUnit, ok := units[to]
+	if !ok {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unknown units '%s'", to), nil)
+	}
+	if fromUnit.quantity != toUnit.quantity {
+		errMsg := fmt.Sprintf("cannot convert %s units '%s' to %s units '%s'", fromUnit.quantity, from, toUnit.quantity, to)
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+	return unitConversion{from: fromUnit, to: toUnit}, nil
+}
+
+func (c unitConversion) read(value float64) (float64, error) {
+	return (value*c.from.factor + c.from.offset - c.to.offset) / c.to.factor, nil
+}
+
+func (c unitConversion) write(value float64) (float64, error) {
+	return (value*c.to.factor + c.to.offset - c.from.offset) / c.from.factor, nil
+}
@@ -0,0 +1,175 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	contractsModels "github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+func unitsResource(name string, valueType string, units string) contractsModels.DeviceResource {
+	return contractsModels.DeviceResource{
+		Name:       name,
+		Properties: contractsModels.ResourceProperties{ValueType: valueType, Units: units},
+	}
+}
+
+func TestConvertReadUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		valueType string
+		value     any
+		from      string
+		to        string
+		expected  any
+	}{
+		{"psi to kPa", common.ValueTypeFloat64, float64(100), "psi", "kPa", 689.4757293168361},
+		{"bar to psi", common.ValueTypeFloat32, float32(1), "bar", "psi", float32(14.503774)},
+		{"F to C", common.ValueTypeFloat64, float64(212), "degF", "degC", float64(100)},
+		{"C to K", common.ValueTypeInt16, int16(25), "C", "K", 298.15},
+		{"psi to bar not rounded", common.ValueTypeInt16, int16(30), "psi", "bar", 2.0684271879505084},
+		{"integer array", common.ValueTypeUint16Array, []uint16{1, 2}, "kWh", "MJ", []float64{3.6, 7.2}},
+		{"kWh to MJ", common.ValueTypeFloat64, float64(1), "kWh", "MJ", 3.6},
+		{"gpm to L/min", common.ValueTypeFloat64, float64(1), "gpm", "L/min", 3.785411784},
+		{"ft to m", common.ValueTypeFloat64Array, []float64{1, 10}, "ft", "m", []float64{0.3048, 3.048}},
+		{"same units", common.ValueTypeFloat64, float64(1), "m", "", float64(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := models.NewCommandValue("r1", tt.valueType, tt.value)
+			require.NoError(t, err)
+			require.NoError(t, convertReadUnits(cv, unitsResource("r1", tt.valueType, tt.from), tt.to))
+			assert.InDeltaSlice(t, toSlice(tt.expected), toSlice(cv.Value), 1e-6)
+			assert.IsType(t, tt.expected, cv.Value)
+			// the converted integer values are Float64 values
+			switch tt.expected.(type) {
+			case float64:
+				assert.Equal(t, common.ValueTypeFloat64, cv.Type)
+			case []float64:
+				assert.Equal(t, common.ValueTypeFloat64Array, cv.Type)
+			}
+		})
+	}
+
+	cv, err := models.NewCommandValue("r1", common.ValueTypeFloat64, float64(1))
+	require.NoError(t, err)
+	edgexErr := convertReadUnits(cv, unitsResource("r1", common.ValueTypeFloat64, "psi"), "m")
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(edgexErr))
+}
+
+func toSlice(value any) []float64 {
+	if values, ok := value.([]float64); ok {
+		return values
+	}
+	return []float64{toFloat64(value)}
+}
+
+// writeInUnits converts the value written in the units and transforms it as done for the SET commands.
+func writeInUnits(cv *models.CommandValue, dr contractsModels.DeviceResource, units string) errors.EdgeX {
+	if edgexErr := ConvertWriteUnits(cv, dr, units); edgexErr != nil {
+		return edgexErr
+	}
+	return TransformWriteParameter(cv, dr)
+}
+
+func TestConvertWriteUnits(t *testing.T) {
+	maximum := 1000.0
+	dr := unitsResource("r1", common.ValueTypeFloat64, "kPa")
+	dr.Properties.Maximum = &maximum
+
+	// the value written in psi is converted to kPa before the Maximum is validated
+	cv, err := models.NewCommandValue("r1", common.ValueTypeFloat64, float64(100))
+	require.NoError(t, err)
+	require.NoError(t, writeInUnits(cv, dr, "psi"))
+	assert.InDelta(t, 689.4757293168361, cv.Value, 1e-9)
+
+	cv, err = models.NewCommandValue("r1", common.ValueTypeFloat64, float64(200))
+	require.NoError(t, err)
+	edgexErr := writeInUnits(cv, dr, "psi")
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(edgexErr))
+
+	cv, err = models.NewCommandValue("r1", common.ValueTypeFloat64, float64(1))
+	require.NoError(t, err)
+	edgexErr = ConvertWriteUnits(cv, dr, "m")
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(edgexErr))
+}
+
+func TestConvertWriteUnits_IntegerUnits(t *testing.T) {
+	dr := unitsResource("r1", common.ValueTypeInt16, "psi")
+	assert.Equal(t, common.ValueTypeFloat64, WriteValueType(dr, "bar"))
+	assert.Equal(t, common.ValueTypeInt16, WriteValueType(dr, ""))
+	assert.Equal(t, common.ValueTypeInt16, WriteValueType(dr, "psi"))
+	assert.Equal(t, common.ValueTypeFloat64Array, WriteValueType(unitsResource("r1", common.ValueTypeInt16Array, "psi"), "bar"))
+	assert.Equal(t, common.ValueTypeFloat32, WriteValueType(unitsResource("r1", common.ValueTypeFloat32, "psi"), "bar"))
+
+	// the value written in bar is parsed as a Float64 value and rounded once converted to psi
+	cv, err := models.NewCommandValue("r1", common.ValueTypeFloat64, 2.5)
+	require.NoError(t, err)
+	require.NoError(t, ConvertWriteUnits(cv, dr, "bar"))
+	assert.Equal(t, common.ValueTypeInt16, cv.Type)
+	assert.Equal(t, int16(36), cv.Value)
+
+	cv, err = models.NewCommandValue("r1", common.ValueTypeFloat64Array, []float64{1, 0.5})
+	require.NoError(t, err)
+	require.NoError(t, writeInUnits(cv, unitsResource("r1", common.ValueTypeUint8Array, "psi"), "bar"))
+	assert.Equal(t, common.ValueTypeUint8Array, cv.Type)
+	assert.Equal(t, []uint8{15, 7}, cv.Value)
+
+	// the converted value out of the range of the ValueType is rejected
+	cv, err = models.NewCommandValue("r1", common.ValueTypeFloat64, float64(100))
+	require.NoError(t, err)
+	edgexErr := writeInUnits(cv, unitsResource("r1", common.ValueTypeInt8, "psi"), "bar")
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(edgexErr))
+}
+
+func TestTargetUnits(t *testing.T) {
+	device := contractsModels.Device{Properties: map[string]any{
+		"ds-units": map[string]any{"pressure": "kPa", "level": "ft"},
+	}}
+	pressure := unitsResource("pressure1", common.ValueTypeFloat64, "psi")
+	level := unitsResource("level", common.ValueTypeFloat64, "m")
+	temperature := unitsResource("temperature", common.ValueTypeFloat64, "degC")
+	unknown := unitsResource("unknown", common.ValueTypeFloat64, "rpm")
+
+	tests := []struct {
+		name      string
+		dr        contractsModels.DeviceResource
+		requested string
+		expected  string
+	}{
+		{"device property quantity", pressure, "", "kPa"},
+		{"device property resource name", level, "", "ft"},
+		{"requested overrides device property", pressure, "bar", "bar"},
+		{"requested other quantity", level, "bar", "ft"},
+		{"no target", temperature, "", ""},
+		{"requested", temperature, "degF", "degF"},
+		{"same units", pressure, "psi", ""},
+		{"unknown resource units", unknown, "bar", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TargetUnits(device, tt.dr, tt.requested))
+		})
+	}
+}
+
+func TestValidateUnits(t *testing.T) {
+	assert.NoError(t, ValidateUnits(""))
+	assert.NoError(t, ValidateUnits("kPa"))
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(ValidateUnits("kpa")))
+}
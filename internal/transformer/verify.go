@@ -0,0 +1,116 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// VerifyWrittenValue compares the value read back from the device resource with the value written to it, both
+// being the values exchanged with the ProtocolDriver, i.e. after the write transforms. The numbers, including
+// the elements of the arrays, may differ by the ds-verifytolerance attribute of the device resource.
+func VerifyWrittenValue(written *sdkModels.CommandValue, read *sdkModels.CommandValue, dr models.DeviceResource) errors.EdgeX {
+	var tolerance float64
+	if option, ok := dr.Attributes[sdkCommon.VerifyTolerance]; ok {
+		var err error
+		tolerance, err = strconv.ParseFloat(fmt.Sprint(option), 64)
+		if err != nil || tolerance < 0 {
+			errMsg := fmt.Sprintf("invalid %s '%v' of DeviceResource %s", sdkCommon.VerifyTolerance, option, dr.Name)
+			return errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+		}
+	}
+
+	if read == nil {
+		errMsg := fmt.Sprintf("no value of DeviceResource %s was read back", dr.Name)
+		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+	}
+	equal, err := equalWrittenValue(written, read, tolerance)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to compare the value read back from DeviceResource %s", dr.Name)
+		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+	}
+	if !equal {
+		errMsg := fmt.Sprintf("DeviceResource %s read back %s instead of the written %s", dr.Name, describeValue(read), describeValue(written))
+		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+	}
+	return nil
+}
+
+func equalWrittenValue(written *sdkModels.CommandValue, read *sdkModels.CommandValue, tolerance float64) (bool, error) {
+	switch written.Type {
+	case common.ValueTypeBinary:
+		writtenBinary, err := written.BinaryValue()
+		if err != nil {
+			return false, err
+		}
+		readBinary, err := read.BinaryValue()
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(writtenBinary, readBinary), nil
+	case common.ValueTypeObject:
+		// the objects are compared as decoded from JSON so that their Go types do not matter
+		var values [2]any
+		for i, v := range []any{written.Value, read.Value} {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return false, err
+			}
+			if err = json.Unmarshal(data, &values[i]); err != nil {
+				return false, err
+			}
+		}
+		return reflect.DeepEqual(values[0], values[1]), nil
+	}
+
+	writtenElements := elements(written.Value)
+	readElements := elements(read.Value)
+	if len(writtenElements) != len(readElements) {
+		return false, nil
+	}
+	for i := range writtenElements {
+		a, aIsNumber := jsonNumber(writtenElements[i])
+		b, bIsNumber := jsonNumber(readElements[i])
+		if aIsNumber && bIsNumber {
+			if math.Abs(a-b) > tolerance {
+				return false, nil
+			}
+		} else if fmt.Sprint(writtenElements[i]) != fmt.Sprint(readElements[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func describeValue(cv *sdkModels.CommandValue) string {
+	if binary, ok := cv.Value.([]byte); ok && cv.Type == common.ValueTypeBinary {
+		return fmt.Sprintf("binary value of %d byte(s)", len(binary))
+	}
+	return fmt.Sprintf("%v", cv.Value)
+}
+
+// elements returns the value, or the elements of the array value.
+func elements(value any) []any {
+	var values []any
+	_ = forEachElement(value, func(v any) error {
+		values = append(values, v)
+		return nil
+	})
+	return values
+}
@@ -0,0 +1,73 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	contractsModels "github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+func TestVerifyWrittenValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		valueType  string
+		written    any
+		read       any
+		attributes map[string]any
+		equal      bool
+	}{
+		{"same string", common.ValueTypeString, "auto", "auto", nil, true},
+		{"other string", common.ValueTypeString, "auto", "manual", nil, false},
+		{"same bool", common.ValueTypeBool, true, true, nil, true},
+		{"clamped integer", common.ValueTypeInt16, int16(120), int16(100), nil, false},
+		{"float within tolerance", common.ValueTypeFloat32, float32(21.5), float32(21.49), map[string]any{"ds-verifytolerance": 0.05}, true},
+		{"float beyond tolerance", common.ValueTypeFloat64, 21.5, 21.4, map[string]any{"ds-verifytolerance": "0.05"}, false},
+		{"same array", common.ValueTypeUint16Array, []uint16{1, 2}, []uint16{1, 2}, nil, true},
+		{"shorter array", common.ValueTypeUint16Array, []uint16{1, 2}, []uint16{1}, nil, false},
+		{"other array element", common.ValueTypeStringArray, []string{"a", "b"}, []string{"a", "c"}, nil, false},
+		{"same object", common.ValueTypeObject, map[string]any{"mode": "auto", "setpoint": 21}, map[string]any{"setpoint": 21.0, "mode": "auto"}, nil, true},
+		{"other object", common.ValueTypeObject, map[string]any{"mode": "auto"}, map[string]any{"mode": "off"}, nil, false},
+		{"same binary", common.ValueTypeBinary, []byte{1, 2}, []byte{1, 2}, nil, true},
+		{"other binary", common.ValueTypeBinary, []byte{1, 2}, []byte{2, 1}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			written, err := models.NewCommandValue("r1", tt.valueType, tt.written)
+			require.NoError(t, err)
+			read, err := models.NewCommandValue("r1", tt.valueType, tt.read)
+			require.NoError(t, err)
+			dr := contractsModels.DeviceResource{Name: "r1", Attributes: tt.attributes}
+
+			edgexErr := VerifyWrittenValue(written, read, dr)
+			if tt.equal {
+				assert.NoError(t, edgexErr)
+				return
+			}
+			require.Error(t, edgexErr)
+			assert.Equal(t, errors.KindServerError, errors.Kind(edgexErr))
+			assert.Contains(t, edgexErr.Error(), "read back")
+		})
+	}
+}
+
+func TestVerifyWrittenValue_NotRead(t *testing.T) {
+	written, err := models.NewCommandValue("r1", common.ValueTypeString, "auto")
+	require.NoError(t, err)
+	edgexErr := VerifyWrittenValue(written, nil, contractsModels.DeviceResource{Name: "r1"})
+	require.Error(t, edgexErr)
+
+	edgexErr = VerifyWrittenValue(written, written, contractsModels.DeviceResource{Name: "r1", Attributes: map[string]any{"ds-verifytolerance": -1}})
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindServerError, errors.Kind(edgexErr))
+}
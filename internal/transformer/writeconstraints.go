@@ -0,0 +1,259 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v3/internal/common"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+// compiledPatterns caches the ds-pattern regular expressions by their source
+var compiledPatterns sync.Map
+
+// ValidateWriteConstraints validates the value written to the device resource against the write constraints
+// of its ResourceProperties: the ds-allowedvalues list, the ds-pattern regular expression which the strings
+// must fully match, the ds-maxlength number of characters of the strings or of elements of the arrays, the
+// ds-step which the numbers must be a multiple of away from the Minimum, and the ds-schema JSON schema of
+// the Object values. The strings are also limited to maxValueLen characters if it is positive. The arrays
+// are validated element-wise. The value must be in the Units of the device resource, see ConvertWriteUnits.
+func ValidateWriteConstraints(cv *sdkModels.CommandValue, dr models.DeviceResource, maxValueLen int) errors.EdgeX {
+	if cv.Type == common.ValueTypeBinary {
+		return nil
+	}
+	constraints := dr.Properties.Optional
+	err := validateWriteLength(cv, constraints, maxValueLen)
+	if err == nil {
+		err = validateWriteSchema(cv, constraints)
+	}
+	if err == nil && cv.Type != common.ValueTypeObject {
+		err = forEachElement(cv.Value, func(value any) error {
+			return validateWriteElement(value, dr.Properties, constraints)
+		})
+	}
+	if err != nil {
+		// the invalid constraints are reported as server errors, the invalid values as contract errors
+		kind := errors.Kind(err)
+		if kind == errors.KindUnknown {
+			kind = errors.KindContractInvalid
+		}
+		errMsg := fmt.Sprintf("invalid value written to DeviceResource %s", dr.Name)
+		return errors.NewCommonEdgeX(kind, errMsg, err)
+	}
+	return nil
+}
+
+func validateWriteLength(cv *sdkModels.CommandValue, constraints map[string]any, maxValueLen int) error {
+	if maxValueLen > 0 {
+		err := forEachElement(cv.Value, func(value any) error {
+			if s, ok := value.(string); ok && utf8.RuneCountInString(s) > maxValueLen {
+				return fmt.Errorf("the string length exceeds the MaxCmdValueLen %d", maxValueLen)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	option, ok := constraints[sdkCommon.MaxLength]
+	if !ok {
+		return nil
+	}
+	maxLength, err := strconv.Atoi(fmt.Sprint(option))
+	if err != nil || maxLength < 0 {
+		return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("invalid %s '%v'", sdkCommon.MaxLength, option), nil)
+	}
+	if cv.Type == common.ValueTypeString {
+		if length := utf8.RuneCountInString(fmt.Sprint(cv.Value)); length > maxLength {
+			return fmt.Errorf("the string length %d exceeds the %s %d", length, sdkCommon.MaxLength, maxLength)
+		}
+	} else if length := arrayLength(cv.Value); length > maxLength {
+		return fmt.Errorf("the array length %d exceeds the %s %d", length, sdkCommon.MaxLength, maxLength)
+	}
+	return nil
+}
+
+func validateWriteSchema(cv *sdkModels.CommandValue, constraints map[string]any) error {
+	schema, ok := constraints[sdkCommon.Schema]
+	if !ok || cv.Type != common.ValueTypeObject {
+		return nil
+	}
+	// the schema may be given as a JSON string instead of an object
+	if s, ok := schema.(string); ok {
+		if err := json.Unmarshal([]byte(s), &schema); err != nil {
+			return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("invalid %s", sdkCommon.Schema), err)
+		}
+	}
+	// normalize the value to the types decoded from JSON
+	data, err := json.Marshal(cv.Value)
+	if err != nil {
+		return fmt.Errorf("failed to encode the Object value: %w", err)
+	}
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode the Object value: %w", err)
+	}
+	return validateSchema(value, schema, "value")
+}
+
+// validateWriteElement validates a string, number or bool value, or an element of an array value.
+func validateWriteElement(value any, properties models.ResourceProperties, constraints map[string]any) error {
+	if allowed, ok := constraints[sdkCommon.AllowedValues]; ok && !isAllowedValue(value, allowed) {
+		return fmt.Errorf("the value %v is not one of the %s %v", value, sdkCommon.AllowedValues, allowed)
+	}
+
+	if pattern, ok := constraints[sdkCommon.Pattern]; ok {
+		if s, ok := value.(string); ok {
+			re, err := compilePattern(fmt.Sprint(pattern))
+			if err != nil {
+				return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("invalid %s '%v'", sdkCommon.Pattern, pattern), err)
+			}
+			if !re.MatchString(s) {
+				return fmt.Errorf("the value '%s' does not match the %s '%v'", s, sdkCommon.Pattern, pattern)
+			}
+		}
+	}
+
+	if option, ok := constraints[sdkCommon.Step]; ok {
+		n, isNumber := jsonNumber(value)
+		if !isNumber {
+			return nil
+		}
+		step, err := strconv.ParseFloat(fmt.Sprint(option), 64)
+		if err != nil || step <= 0 {
+			return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("invalid %s '%v'", sdkCommon.Step, option), nil)
+		}
+		var base float64
+		if properties.Minimum != nil {
+			base = *properties.Minimum
+		}
+		if !isMultipleOf(n, base, step) {
+			return fmt.Errorf("the value %v is not a multiple of the %s %v from %v", value, sdkCommon.Step, step, base)
+		}
+	}
+	return nil
+}
+
+// isAllowedValue checks whether the value is in the list of allowed values, given either as a list or as
+// a comma separated string. The numbers are compared by value, the other values by their string form.
+func isAllowedValue(value any, allowed any) bool {
+	var values []any
+	switch v := allowed.(type) {
+	case []any:
+		values = v
+	default:
+		for _, s := range strings.Split(fmt.Sprint(v), ",") {
+			values = append(values, strings.TrimSpace(s))
+		}
+	}
+
+	n, isNumber := jsonNumber(value)
+	for _, v := range values {
+		if isNumber {
+			if a, err := strconv.ParseFloat(fmt.Sprint(v), 64); err == nil && a == n {
+				return true
+			}
+		} else if fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+	compiledPatterns.Store(pattern, re)
+	return re, nil
+}
+
+// forEachElement calls the function with the value, or with each element of the array value.
+func forEachElement(value any, f func(value any) error) error {
+	switch v := value.(type) {
+	case []string:
+		return forEach(v, f)
+	case []bool:
+		return forEach(v, f)
+	case []uint8:
+		return forEach(v, f)
+	case []uint16:
+		return forEach(v, f)
+	case []uint32:
+		return forEach(v, f)
+	case []uint64:
+		return forEach(v, f)
+	case []int8:
+		return forEach(v, f)
+	case []int16:
+		return forEach(v, f)
+	case []int32:
+		return forEach(v, f)
+	case []int64:
+		return forEach(v, f)
+	case []float32:
+		return forEach(v, f)
+	case []float64:
+		return forEach(v, f)
+	}
+	return f(value)
+}
+
+func forEach[T any](values []T, f func(value any) error) error {
+	for i, v := range values {
+		if err := f(v); err != nil {
+			return fmt.Errorf("array element %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func arrayLength(value any) int {
+	switch v := value.(type) {
+	case []string:
+		return len(v)
+	case []bool:
+		return len(v)
+	case []uint8:
+		return len(v)
+	case []uint16:
+		return len(v)
+	case []uint32:
+		return len(v)
+	case []uint64:
+		return len(v)
+	case []int8:
+		return len(v)
+	case []int16:
+		return len(v)
+	case []int32:
+		return len(v)
+	case []int64:
+		return len(v)
+	case []float32:
+		return len(v)
+	case []float64:
+		return len(v)
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	contractsModels "github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
+func TestValidateWriteConstraints(t *testing.T) {
+	minimum := 10.0
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"mode"},
+		"properties": map[string]any{
+			"mode":     map[string]any{"enum": []any{"auto", "manual"}},
+			"setpoint": map[string]any{"type": "number", "minimum": 0, "maximum": 100, "multipleOf": 0.5},
+			"zones":    map[string]any{"type": "array", "maxItems": 2, "items": map[string]any{"type": "integer"}},
+		},
+		"additionalProperties": false,
+	}
+
+	tests := []struct {
+		name        string
+		valueType   string
+		value       any
+		constraints map[string]any
+		maxValueLen int
+		errorKind   errors.ErrKind
+	}{
+		{"allowed string", common.ValueTypeString, "auto", map[string]any{"ds-allowedvalues": []any{"auto", "manual"}}, 0, ""},
+		{"not allowed string", common.ValueTypeString, "off", map[string]any{"ds-allowedvalues": []any{"auto", "manual"}}, 0, errors.KindContractInvalid},
+		{"allowed number", common.ValueTypeInt32, int32(2), map[string]any{"ds-allowedvalues": "1, 2.0, 3"}, 0, ""},
+		{"not allowed array element", common.ValueTypeUint8Array, []uint8{1, 4}, map[string]any{"ds-allowedvalues": []any{1, 2, 3}}, 0, errors.KindContractInvalid},
+		{"pattern", common.ValueTypeString, "AB-12", map[string]any{"ds-pattern": "[A-Z]+-[0-9]+"}, 0, ""},
+		{"pattern partial match", common.ValueTypeString, "AB-12x", map[string]any{"ds-pattern": "[A-Z]+-[0-9]+"}, 0, errors.KindContractInvalid},
+		{"pattern array element", common.ValueTypeStringArray, []string{"AB-1", "ab-2"}, map[string]any{"ds-pattern": "[A-Z]+-[0-9]+"}, 0, errors.KindContractInvalid},
+		{"invalid pattern", common.ValueTypeString, "a", map[string]any{"ds-pattern": "("}, 0, errors.KindServerError},
+		{"max length", common.ValueTypeString, "ñandú", map[string]any{"ds-maxlength": 5}, 0, ""},
+		{"above max length", common.ValueTypeString, "abcdef", map[string]any{"ds-maxlength": 5}, 0, errors.KindContractInvalid},
+		{"above max array length", common.ValueTypeFloat32Array, []float32{1, 2, 3}, map[string]any{"ds-maxlength": "2"}, 0, errors.KindContractInvalid},
+		{"above MaxCmdValueLen", common.ValueTypeString, "abcdef", nil, 5, errors.KindContractInvalid},
+		{"step from minimum", common.ValueTypeFloat64, 11.5, map[string]any{"ds-step": 0.5}, 0, ""},
+		{"not a step from minimum", common.ValueTypeInt16, int16(13), map[string]any{"ds-step": 2}, 0, errors.KindContractInvalid},
+		{"step array element", common.ValueTypeInt16Array, []int16{12, 14, 15}, map[string]any{"ds-step": 2}, 0, errors.KindContractInvalid},
+		{"invalid step", common.ValueTypeInt16, int16(12), map[string]any{"ds-step": 0}, 0, errors.KindServerError},
+		{"schema", common.ValueTypeObject, map[string]any{"mode": "auto", "setpoint": 21.5, "zones": []any{1, 2}}, map[string]any{"ds-schema": schema}, 0, ""},
+		{"schema missing required", common.ValueTypeObject, map[string]any{"setpoint": 21.5}, map[string]any{"ds-schema": schema}, 0, errors.KindContractInvalid},
+		{"schema enum", common.ValueTypeObject, map[string]any{"mode": "off"}, map[string]any{"ds-schema": schema}, 0, errors.KindContractInvalid},
+		{"schema multipleOf", common.ValueTypeObject, map[string]any{"mode": "auto", "setpoint": 21.2}, map[string]any{"ds-schema": schema}, 0, errors.KindContractInvalid},
+		{"schema items", common.ValueTypeObject, map[string]any{"mode": "auto", "zones": []any{1.5}}, map[string]any{"ds-schema": schema}, 0, errors.KindContractInvalid},
+		{"schema maxItems", common.ValueTypeObject, map[string]any{"mode": "auto", "zones": []any{1, 2, 3}}, map[string]any{"ds-schema": schema}, 0, errors.KindContractInvalid},
+		{"schema additional property", common.ValueTypeObject, map[string]any{"mode": "auto", "other": 1}, map[string]any{"ds-schema": schema}, 0, errors.KindContractInvalid},
+		{"schema as JSON string", common.ValueTypeObject, map[string]any{"mode": 1}, map[string]any{"ds-schema": `{"properties": {"mode": {"type": "string"}}}`}, 0, errors.KindContractInvalid},
+		{"no constraints", common.ValueTypeString, "abcdef", nil, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := models.NewCommandValue("r1", tt.valueType, tt.value)
+			require.NoError(t, err)
+			dr := contractsModels.DeviceResource{
+				Name:       "r1",
+				Properties: contractsModels.ResourceProperties{ValueType: tt.valueType, Minimum: &minimum, Optional: tt.constraints},
+			}
+
+			edgexErr := ValidateWriteConstraints(cv, dr, tt.maxValueLen)
+			if tt.errorKind == "" {
+				assert.NoError(t, edgexErr)
+				return
+			}
+			require.Error(t, edgexErr)
+			assert.Equal(t, tt.errorKind, errors.Kind(edgexErr))
+		})
+	}
+}
@@ -5,6 +5,12 @@
 
 package interfaces
 
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
+)
+
 type AutoEventManager interface {
 	// StartAutoEvents starts all the AutoEvents of the device service
 	StartAutoEvents()
@@ -12,4 +18,13 @@ type AutoEventManager interface {
 	RestartForDevice(name string)
 	// StopForDevice stops all the AutoEvents of the specific device
 	StopForDevice(name string)
+	// AutoEventStatuses returns the status of the running AutoEvents of the specific device,
+	// or of all the devices if the name is empty
+	AutoEventStatuses(name string) ([]sdkModels.AutoEventStatus, errors.EdgeX)
+	// PauseAutoEvent stops executing the AutoEvent of the specific device and source until it is resumed
+	PauseAutoEvent(name string, sourceName string) errors.EdgeX
+	// ResumeAutoEvent resumes executing the paused AutoEvent of the specific device and source
+	ResumeAutoEvent(name string, sourceName string) errors.EdgeX
+	// TriggerAutoEvent executes the AutoEvent of the specific device and source immediately, even if it is paused
+	TriggerAutoEvent(name string, sourceName string) errors.EdgeX
 }
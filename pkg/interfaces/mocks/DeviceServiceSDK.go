@@ -160,6 +160,32 @@ func (_m *DeviceServiceSDK) AsyncValuesChannel() chan *pkgmodels.AsyncValues {
 	return r0
 }
 
+// AutoEventStatuses provides a mock function with given fields: deviceName
+func (_m *DeviceServiceSDK) AutoEventStatuses(deviceName string) ([]pkgmodels.AutoEventStatus, error) {
+	ret := _m.Called(deviceName)
+
+	var r0 []pkgmodels.AutoEventStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]pkgmodels.AutoEventStatus, error)); ok {
+		return rf(deviceName)
+	}
+	if rf, ok := ret.Get(0).(func(string) []pkgmodels.AutoEventStatus); ok {
+		r0 = rf(deviceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgmodels.AutoEventStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(deviceName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceCommand provides a mock function with given fields: deviceName, commandName
 func (_m *DeviceServiceSDK) DeviceCommand(deviceName string, commandName string) (models.DeviceCommand, bool) {
 	ret := _m.Called(deviceName, commandName)
@@ -432,6 +458,20 @@ func (_m *DeviceServiceSDK) Name() string {
 	return r0
 }
 
+// PauseAutoEvent provides a mock function with given fields: deviceName, sourceName
+func (_m *DeviceServiceSDK) PauseAutoEvent(deviceName string, sourceName string) error {
+	ret := _m.Called(deviceName, sourceName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(deviceName, sourceName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ProvisionWatchers provides a mock function with given fields:
 func (_m *DeviceServiceSDK) ProvisionWatchers() []models.ProvisionWatcher {
 	ret := _m.Called()
@@ -448,6 +488,20 @@ func (_m *DeviceServiceSDK) ProvisionWatchers() []models.ProvisionWatcher {
 	return r0
 }
 
+// RegisterTransform provides a mock function with given fields: name, transform
+func (_m *DeviceServiceSDK) RegisterTransform(name string, transform interfaces.Transform) error {
+	ret := _m.Called(name, transform)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, interfaces.Transform) error); ok {
+		r0 = rf(name, transform)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveDeviceAutoEvent provides a mock function with given fields: deviceName, event
 func (_m *DeviceServiceSDK) RemoveDeviceAutoEvent(deviceName string, event models.AutoEvent) error {
 	ret := _m.Called(deviceName, event)
@@ -504,6 +558,20 @@ func (_m *DeviceServiceSDK) RemoveProvisionWatcher(name string) error {
 	return r0
 }
 
+// ResumeAutoEvent provides a mock function with given fields: deviceName, sourceName
+func (_m *DeviceServiceSDK) ResumeAutoEvent(deviceName string, sourceName string) error {
+	ret := _m.Called(deviceName, sourceName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(deviceName, sourceName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SecretProvider provides a mock function with given fields:
 func (_m *DeviceServiceSDK) SecretProvider() bootstrapinterfaces.SecretProvider {
 	ret := _m.Called()
@@ -534,6 +602,20 @@ func (_m *DeviceServiceSDK) SetDeviceOpState(name string, state models.Operating
 	return r0
 }
 
+// TriggerAutoEvent provides a mock function with given fields: deviceName, sourceName
+func (_m *DeviceServiceSDK) TriggerAutoEvent(deviceName string, sourceName string) error {
+	ret := _m.Called(deviceName, sourceName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(deviceName, sourceName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDevice provides a mock function with given fields: device
 func (_m *DeviceServiceSDK) UpdateDevice(device models.Device) error {
 	ret := _m.Called(device)
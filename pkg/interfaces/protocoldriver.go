@@ -12,6 +12,8 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 
 	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
@@ -28,7 +30,9 @@ type ProtocolDriver interface {
 	Initialize(sdk DeviceServiceSDK) error
 
 	// HandleReadCommands passes a slice of CommandRequest struct each representing
-	// a ResourceOperation for a specific device resource.
+	// a ResourceOperation for a specific device resource. If only some of the resources
+	// failed to be read, a *models.PartialReadError can be returned along with the values
+	// of the other ones.
 	HandleReadCommands(deviceName string, protocols map[string]models.ProtocolProperties, reqs []sdkModels.CommandRequest) ([]*sdkModels.CommandValue, error)
 
 	// HandleWriteCommands passes a slice of CommandRequest struct each representing
@@ -69,3 +73,17 @@ type ProtocolDriver interface {
 	// if validation failed and the incoming device will not be added into EdgeX.
 	ValidateDevice(device models.Device) error
 }
+
+// ContextualProtocolDriver is an optional interface which can be implemented by a ProtocolDriver
+// in addition to the ProtocolDriver interface. When the driver implements it, the SDK invokes
+// these methods instead of HandleReadCommands and HandleWriteCommands, so that the driver can
+// abort an in-flight operation once the request is canceled or its command timeout elapses.
+type ContextualProtocolDriver interface {
+	// HandleReadCommandsWithContext behaves like ProtocolDriver.HandleReadCommands. The given ctx is
+	// canceled when the caller (e.g. a REST client) goes away or when the configured command timeout expires.
+	HandleReadCommandsWithContext(ctx context.Context, deviceName string, protocols map[string]models.ProtocolProperties, reqs []sdkModels.CommandRequest) ([]*sdkModels.CommandValue, error)
+
+	// HandleWriteCommandsWithContext behaves like ProtocolDriver.HandleWriteCommands. The given ctx is
+	// canceled when the caller (e.g. a REST client) goes away or when the configured command timeout expires.
+	HandleWriteCommandsWithContext(ctx context.Context, deviceName string, protocols map[string]models.ProtocolProperties, reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue) error
+}
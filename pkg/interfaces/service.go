@@ -77,6 +77,16 @@ type DeviceServiceSDK interface {
 	AddDeviceAutoEvent(deviceName string, event models.AutoEvent) error
 	// RemoveDeviceAutoEvent removes an AutoEvent from the Device with given name
 	RemoveDeviceAutoEvent(deviceName string, event models.AutoEvent) error
+	// AutoEventStatuses returns the runtime status of the AutoEvents of the Device with given name,
+	// or of all the Devices if the name is empty
+	AutoEventStatuses(deviceName string) ([]sdkModels.AutoEventStatus, error)
+	// PauseAutoEvent pauses the AutoEvent of the Device with given name and source until it is resumed,
+	// without updating the Device in Core Metadata
+	PauseAutoEvent(deviceName string, sourceName string) error
+	// ResumeAutoEvent resumes the paused AutoEvent of the Device with given name and source
+	ResumeAutoEvent(deviceName string, sourceName string) error
+	// TriggerAutoEvent executes the AutoEvent of the Device with given name and source immediately
+	TriggerAutoEvent(deviceName string, sourceName string) error
 	// SetDeviceOpState sets the operating state of device
 	SetDeviceOpState(name string, state models.OperatingState) error
 	// UpdateDeviceOperatingState updates the Device's OperatingState with given name
@@ -107,6 +117,11 @@ type DeviceServiceSDK interface {
 	// AddRoute allows leveraging the existing internal web server to add routes specific to Device Service.
 	AddRoute(route string, handler func(http.ResponseWriter, *http.Request), methods ...string) error
 
+	// RegisterTransform registers a custom transform, which the device resources apply to their numeric values
+	// by listing an entry of the given type in their ds-transforms attribute. The built-in transform types
+	// expression, lookup, piecewise and clamp cannot be replaced.
+	RegisterTransform(name string, transform Transform) error
+
 	// LoadCustomConfig uses the Config Processor from go-mod-bootstrap to attempt to load service's
 	// custom configuration. It uses the same command line flags to process the custom config in the same manner
 	// as the standard configuration.
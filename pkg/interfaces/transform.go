@@ -0,0 +1,16 @@
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package interfaces
+
+// Transform is a custom transformation of the numeric values of device resources. It is registered by
+// name with DeviceServiceSDK.RegisterTransform, and applied by the device resources listing an entry of
+// this type in their ds-transforms attribute. The options are the fields of that entry.
+type Transform interface {
+	// Read transforms a value read from the device.
+	Read(value float64, options map[string]any) (float64, error)
+	// Write transforms a value written to the device, it is the inverse of Read.
+	Write(value float64, options map[string]any) (float64, error)
+}
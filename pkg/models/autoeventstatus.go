@@ -0,0 +1,23 @@
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// AutoEventStatus is the runtime status of an AutoEvent executed by the device service.
+// The times are Unix timestamps in nanoseconds, zero if the AutoEvent has not run yet.
+type AutoEventStatus struct {
+	DeviceName string `json:"deviceName"`
+	SourceName string `json:"sourceName"`
+	Interval   string `json:"interval,omitempty"`
+	// Schedule is the cron expression replacing the Interval, if any
+	Schedule            string `json:"schedule,omitempty"`
+	OnChange            bool   `json:"onChange"`
+	Paused              bool   `json:"paused"`
+	LastRun             int64  `json:"lastRun,omitempty"`
+	NextRun             int64  `json:"nextRun,omitempty"`
+	LastError           string `json:"lastError,omitempty"`
+	ConsecutiveFailures int    `json:"consecutiveFailures"`
+	EventsPublished     uint64 `json:"eventsPublished"`
+}
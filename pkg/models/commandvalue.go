@@ -15,6 +15,18 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 )
 
+// The qualities of the reading values
+const (
+	// QualityGood is the quality of a value read reliably
+	QualityGood = "Good"
+	// QualityUncertain is the quality of a value read, but whose accuracy is uncertain
+	QualityUncertain = "Uncertain"
+	// QualityStale is the quality of a value which was not read from the device by the current read
+	QualityStale = "Stale"
+	// QualityBad is the quality of a value which is not usable, such as the value of a faulty sensor
+	QualityBad = "Bad"
+)
+
 const (
 	// Policy limits should be located in global config namespace
 	// Currently assigning 16MB (binary), 16 * 2^20 bytes
@@ -39,6 +51,11 @@ type CommandValue struct {
 	// Tags allows device service to add custom information to the Event in order to
 	// help identify its origin or otherwise label it before it is send to north side.
 	Tags map[string]string
+	// Quality is the optional quality of the reading value, one of the Quality constants, which
+	// is added to the tags of the reading. It is set by the SDK for the values which overflow, are
+	// NaN or are read from the cache, and for the values failing their assertion if the
+	// Writable.Reading.FlagFailedAssertions configuration is enabled.
+	Quality string
 }
 
 // NewCommandValue create a CommandValue according to the valueType supplied.
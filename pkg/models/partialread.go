@@ -0,0 +1,50 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PartialReadError is returned by ProtocolDriver.HandleReadCommands along with the CommandValues of the
+// resources which were read successfully, when only some of the requested resources failed to be read.
+// If the PartialReads setting of the device service is enabled, the successful values are published and
+// the failed resources are reported in the command response, otherwise the whole read fails.
+type PartialReadError struct {
+	// Errors are the errors of the resources which failed to be read, keyed by DeviceResourceName
+	Errors map[string]error
+}
+
+// NewPartialReadError creates a PartialReadError without any failed resource.
+func NewPartialReadError() *PartialReadError {
+	return &PartialReadError{Errors: make(map[string]error)}
+}
+
+// Add records the error of the resource which failed to be read.
+func (e *PartialReadError) Add(deviceResourceName string, err error) {
+	if e.Errors == nil {
+		e.Errors = make(map[string]error)
+	}
+	e.Errors[deviceResourceName] = err
+}
+
+// Error returns the errors of the failed resources sorted by their name.
+func (e *PartialReadError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	failures := make([]string, len(names))
+	for i, name := range names {
+		failures[i] = fmt.Sprintf("%s: %v", name, e.Errors[name])
+	}
+	return fmt.Sprintf("failed to read %d resource(s): %s", len(names), strings.Join(failures, "; "))
+}
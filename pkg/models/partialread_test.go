@@ -0,0 +1,33 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2023 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialReadError(t *testing.T) {
+	partialErr := NewPartialReadError()
+	partialErr.Add("temperature", errors.New("timeout"))
+	partialErr.Add("humidity", errors.New("illegal address"))
+	assert.Equal(t, "failed to read 2 resource(s): humidity: illegal address; temperature: timeout", partialErr.Error())
+
+	// the drivers may wrap the error
+	var err error = fmt.Errorf("modbus: %w", partialErr)
+	var target *PartialReadError
+	require.ErrorAs(t, err, &target)
+	assert.Len(t, target.Errors, 2)
+
+	var zero PartialReadError
+	zero.Add("pressure", errors.New("timeout"))
+	assert.Len(t, zero.Errors, 1)
+}
@@ -67,8 +67,12 @@ func (s *deviceService) sendAsyncValues(acv *sdkModels.AsyncValues, working chan
 		acv.SourceName = acv.CommandValues[0].DeviceResourceName
 	}
 
+	for _, cv := range acv.CommandValues {
+		cache.Readings().Add(acv.DeviceName, cv)
+	}
+
 	configuration := container.ConfigurationFrom(dic.Get)
-	event, err := transformer.CommandValuesToEventDTO(acv.CommandValues, acv.DeviceName, acv.SourceName, configuration.Device.DataTransform, dic)
+	event, err := transformer.CommandValuesToEventDTO(acv.CommandValues, acv.DeviceName, acv.SourceName, configuration.Device.DataTransform, "", dic)
 	if err != nil {
 		s.lc.Errorf("failed to transform CommandValues to Event: %v", err)
 		return
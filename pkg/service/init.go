@@ -107,6 +107,18 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _
 	// Very important that this bootstrap handler is called after the NewServiceMetrics handler so
 	// MetricsManager dependency has been created.
 	common.InitializeSentMetrics(s.lc, dic)
+
+	edgexErr = common.InitializeEventBuffer(ctx, wg, dic)
+	if edgexErr != nil {
+		s.lc.Errorf("Failed to init event buffer: %s", edgexErr.Error())
+		return false
+	}
+
+	edgexErr = common.InitializeEventBatching(ctx, wg, dic)
+	if edgexErr != nil {
+		s.lc.Errorf("Failed to init event batching: %s", edgexErr.Error())
+		return false
+	}
 	return true
 }
 
@@ -14,6 +14,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/cache"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
 )
 
 // AddDeviceAutoEvent adds a new AutoEvent to the Device with given name
@@ -76,3 +77,32 @@ func (s *deviceService) RemoveDeviceAutoEvent(deviceName string, event models.Au
 
 	return nil
 }
+
+// AutoEventStatuses returns the runtime status of the AutoEvents of the Device with given name,
+// or of all the Devices if the name is empty
+func (s *deviceService) AutoEventStatuses(deviceName string) ([]sdkModels.AutoEventStatus, error) {
+	return s.autoEventManager.AutoEventStatuses(deviceName)
+}
+
+// PauseAutoEvent pauses the AutoEvent of the Device with given name and source until it is resumed
+func (s *deviceService) PauseAutoEvent(deviceName string, sourceName string) error {
+	if err := s.autoEventManager.PauseAutoEvent(deviceName, sourceName); err != nil {
+		return err
+	}
+	s.lc.Infof("AutoEvent %s of device %s paused", sourceName, deviceName)
+	return nil
+}
+
+// ResumeAutoEvent resumes the paused AutoEvent of the Device with given name and source
+func (s *deviceService) ResumeAutoEvent(deviceName string, sourceName string) error {
+	if err := s.autoEventManager.ResumeAutoEvent(deviceName, sourceName); err != nil {
+		return err
+	}
+	s.lc.Infof("AutoEvent %s of device %s resumed", sourceName, deviceName)
+	return nil
+}
+
+// TriggerAutoEvent executes the AutoEvent of the Device with given name and source immediately
+func (s *deviceService) TriggerAutoEvent(deviceName string, sourceName string) error {
+	return s.autoEventManager.TriggerAutoEvent(deviceName, sourceName)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/config"
 	"github.com/edgexfoundry/device-sdk-go/v3/internal/container"
 	restController "github.com/edgexfoundry/device-sdk-go/v3/internal/controller/http"
+	"github.com/edgexfoundry/device-sdk-go/v3/internal/transformer"
 	"github.com/edgexfoundry/device-sdk-go/v3/pkg/interfaces"
 	sdkModels "github.com/edgexfoundry/device-sdk-go/v3/pkg/models"
 
@@ -210,6 +211,12 @@ func (s *deviceService) AddRoute(route string, handler func(http.ResponseWriter,
 	return s.controller.AddRoute(route, handler, methods...)
 }
 
+// RegisterTransform registers a custom transform, which the device resources apply to their numeric values
+// by listing an entry of the given type in their ds-transforms attribute.
+func (s *deviceService) RegisterTransform(name string, transform interfaces.Transform) error {
+	return transformer.RegisterTransform(name, transform)
+}
+
 // LoadCustomConfig uses the Config Processor from go-mod-bootstrap to attempt to load service's
 // custom configuration. It uses the same command line flags to process the custom config in the same manner
 // as the standard configuration.